@@ -16,10 +16,16 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"path/filepath"
+	"slices"
 
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources"
 	"github.com/urfave/cli/v3"
 )
 
@@ -28,14 +34,176 @@ var loadCommand cli.Command = cli.Command{
 	Usage:       "Load and start MCP server from existing config file",
 	Description: "Loads a MakeMCP configuration file and starts the MCP server with the saved configuration.",
 	ArgsUsage:   "<config-file-path>",
-	Action:      handleLoadCommand,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "env",
+			Usage: "Environment of the config file merged into its parameters, e.g. staging or production; defaults to the " + core.EnvironmentVariable + " environment variable.",
+		},
+		&cli.StringFlag{
+			Name:  "profile",
+			Usage: "Tool profile of the config file enabled at startup, overriding the saved profile; use 'all' to enable all tools.",
+		},
+	},
+	Action: handleLoadCommand,
+}
+
+var diffCommand cli.Command = cli.Command{
+	Name:        "diff",
+	Usage:       "Compare the tools of a config file with the current spec",
+	Description: "Parses the spec referenced by a MakeMCP configuration file again and lists the added, removed and changed tools. Exits with an error if any change is breaking, e.g. to detect spec drift in CI.",
+	ArgsUsage:   "<config-file-path>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print the changes as JSON.",
+		},
+	},
+	Action: handleDiffCommand,
+}
+
+var validateCommand cli.Command = cli.Command{
+	Name:        "validate",
+	Usage:       "Check a config file or spec for problems exposing its tools over MCP",
+	Description: "Reports invalid and duplicate tool names, missing and overly long descriptions, source-specific problems and the estimated token size of the tool list. Exits with an error if any error is found.",
+	ArgsUsage:   "<config-file-or-spec>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "source",
+			Value: "openapi",
+			Usage: "Source type used to parse specs, ignored for config files.",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print the report as JSON.",
+		},
+	},
+	Action: handleValidateCommand,
+}
+
+var migrateCommand cli.Command = cli.Command{
+	Name:        "migrate",
+	Usage:       "Upgrade config files to the current schema version",
+	Description: "Rewrites MakeMCP configuration files written by older versions in the current format, keeping secret references and YAML comments.",
+	ArgsUsage:   "<config-file-path>...",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "check",
+			Usage: "Only report config files that need to be migrated, exiting with an error if any does.",
+		},
+	},
+	Action: handleMigrateCommand,
 }
 
 // GetInternalCommands returns all CLI commands related to MakeMCP config file management.
 func GetInternalCommands() []*cli.Command {
+	load := loadCommand
+	load.Flags = append(slices.Clone(loadCommand.Flags), getLoadOverrideFlags()...)
 	return []*cli.Command{
-		&loadCommand,
+		&load,
+		&diffCommand,
+		&validateCommand,
+		&migrateCommand,
+	}
+}
+
+// loadIgnoredFlags are default flags that only apply when creating a config file.
+var loadIgnoredFlags = []string{"config-only", "file", "format"}
+
+// getLoadOverrideFlags returns the default flags and the override flags of all sources
+// that can be applied on top of a loaded config file.
+func getLoadOverrideFlags() []cli.Flag {
+	var flags []cli.Flag
+	names := map[string]bool{}
+	add := func(flag cli.Flag) {
+		name := flag.Names()[0]
+		if names[name] || slices.Contains(loadIgnoredFlags, name) {
+			return
+		}
+		names[name] = true
+		flags = append(flags, flag)
+	}
+	for _, flag := range defaultFlags {
+		add(flag)
+	}
+	registered := sources.SourcesRegistry.GetAll()
+	sourceNames := slices.Sorted(maps.Keys(registered))
+	for _, name := range sourceNames {
+		if overridable, ok := registered[name].(sources.OverridableSource); ok {
+			for _, flag := range overridable.GetOverrideFlags() {
+				add(flag)
+			}
+		}
+	}
+	return flags
+}
+
+// applyLoadOverrides applies the flags set on the load command to the params of a loaded config file.
+func applyLoadOverrides(cmd *cli.Command, source sources.MakeMCPSource, app *core.MakeMCPApp) error {
+	shared := app.AppParams.GetSharedParams()
+	if shared == nil {
+		return fmt.Errorf("config file has no shared parameters to override")
+	}
+	if cmd.IsSet("transport") {
+		transport := core.TransportType(cmd.String("transport"))
+		if !transport.IsValid() {
+			return fmt.Errorf("invalid transport type: %s", transport)
+		}
+		shared.Transport = transport
+	}
+	if cmd.IsSet("port") {
+		shared.Port = cmd.String("port")
+	}
+	if cmd.IsSet("dev-mode") {
+		shared.DevMode = cmd.Bool("dev-mode")
+	}
+	if cmd.IsSet("discovery") {
+		shared.Discovery = cmd.Bool("discovery")
+	}
+	if cmd.IsSet("watch") {
+		shared.Watch = cmd.Bool("watch")
+	}
+	if cmd.IsSet("profile") {
+		shared.Profile = cmd.String("profile")
+	}
+
+	overridable, ok := source.(sources.OverridableSource)
+	if !ok {
+		return nil
+	}
+	overrides := map[string]any{}
+	for _, flag := range overridable.GetOverrideFlags() {
+		name := flag.Names()[0]
+		if cmd.IsSet(name) {
+			overrides[name] = cmd.Value(name)
+		}
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	if err := overridable.ApplyOverrides(app.AppParams, overrides); err != nil {
+		return fmt.Errorf("failed to apply %s overrides: %w", source.Name(), err)
+	}
+	return nil
+}
+
+// loadWithOverrides reads a config file in the selected environment, applies the load command overrides
+// and attaches the tool handlers.
+func loadWithOverrides(cmd *cli.Command, configPath string) (*core.MakeMCPApp, error) {
+	environment := core.SelectedEnvironment(cmd.String("env"))
+	app, source, err := ReadConfigFile(configPath, environment)
+	if err != nil {
+		return nil, err
+	}
+	if environment != "" {
+		log.Printf("Using environment %s of the configuration", environment)
 	}
+	if err := applyLoadOverrides(cmd, source, app); err != nil {
+		return nil, err
+	}
+	if err := source.AttachToolHandlers(app); err != nil {
+		return nil, fmt.Errorf("failed to attach tool handlers: %w", err)
+	}
+	return app, nil
 }
 
 // handleLoadCommand handles the load command to start server from config file.
@@ -56,15 +224,209 @@ func handleLoadCommand(ctx context.Context, cmd *cli.Command) error {
 		log.Printf("Loading MakeMCP configuration from: %s", absPath)
 	}
 
-	// Load configuration from file
+	// Load configuration from file, applying the overrides given as flags
 	// Note: app is loaded with tool handlers attached
-	app, err := LoadFromFile(configPath)
+	app, err := loadWithOverrides(cmd, configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration from %s: %w", configPath, err)
 	}
 
 	log.Printf("Loaded configuration for MCP server: %s v%s", app.Name, app.Version)
 
-	// Start server using existing logic
+	// Start server using existing logic, reloading the config file on changes in watch mode
+	if app.AppParams.GetSharedParams().Watch {
+		watcher := NewWatcher([]string{configPath}, func() (*core.MakeMCPApp, error) {
+			return loadWithOverrides(cmd, configPath)
+		})
+		return StartWatchedServer(app, watcher)
+	}
 	return StartServer(app)
 }
+
+// handleDiffCommand handles the diff command comparing a config file with its current spec.
+func handleDiffCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) != 1 {
+		return fmt.Errorf("diff command requires exactly one argument: the path to the config file")
+	}
+	configPath := args[0]
+
+	// Only the spec locations are resolved, the API is not called
+	saved, source, err := readConfigFile(configPath, core.SelectedEnvironment(""), isSpecLocation)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration from %s: %w", configPath, err)
+	}
+	current, err := source.Parse(saved.AppParams)
+	if err != nil {
+		return fmt.Errorf("failed to parse the current spec with %s source: %w", source.Name(), err)
+	}
+	changes := core.CompareTools(saved.Tools, current.Tools)
+
+	out := cmd.Root().Writer
+	if cmd.Bool("json") {
+		if changes == nil {
+			changes = []core.ToolChange{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(changes); err != nil {
+			return fmt.Errorf("failed to encode changes: %w", err)
+		}
+	} else {
+		printToolChanges(out, configPath, changes)
+	}
+
+	if core.HasBreakingChanges(changes) {
+		return fmt.Errorf("%s has breaking changes compared to the current spec", configPath)
+	}
+	return nil
+}
+
+// specLocationKeys are the source params holding the locations of the spec and the documents applied to it.
+var specLocationKeys = []string{"specs", "overlays", "arazzo"}
+
+// isSpecLocation returns true if path is a spec location in the source params of a config file.
+func isSpecLocation(path []string) bool {
+	return len(path) >= 2 && path[0] == "config" && slices.Contains(specLocationKeys, path[1])
+}
+
+// printToolChanges prints changes in human-readable form, breaking changes are marked with "!".
+func printToolChanges(out io.Writer, configPath string, changes []core.ToolChange) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(out, "%s is up to date with the current spec\n", configPath)
+		return
+	}
+	breaking := 0
+	for _, change := range changes {
+		if change.Breaking {
+			breaking++
+		}
+	}
+	_, _ = fmt.Fprintf(out, "%s differs from the current spec: %d changes, %d breaking\n", configPath, len(changes), breaking)
+	for _, change := range changes {
+		_, _ = fmt.Fprintln(out, change.String())
+	}
+}
+
+// handleValidateCommand handles the validate command linting the tools of a config file or spec.
+func handleValidateCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) != 1 {
+		return fmt.Errorf("validate command requires exactly one argument: the path to a config file or spec")
+	}
+	location := args[0]
+
+	var app *core.MakeMCPApp
+	if isConfigFile(location) {
+		// References are not resolved, so no secrets are needed and no commands are run
+		config, _, err := readConfigFile(location, "", nil)
+		if err != nil {
+			return fmt.Errorf("failed to read configuration from %s: %w", location, err)
+		}
+		app = config
+	} else {
+		source := sources.SourcesRegistry.Get(cmd.String("source"))
+		if source == nil {
+			return fmt.Errorf("unknown source type: %s", cmd.String("source"))
+		}
+		parser, ok := source.(sources.SpecParser)
+		if !ok {
+			return fmt.Errorf("%s source cannot parse specs without a config file", source.Name())
+		}
+		spec, err := parser.ParseSpec(location)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s with %s source: %w", location, source.Name(), err)
+		}
+		app = spec
+	}
+	report := core.LintTools(app.Tools)
+
+	out := cmd.Root().Writer
+	if cmd.Bool("json") {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		_, _ = fmt.Fprintf(out, "%s: %d tools, about %d tokens\n", location, report.Tools, report.EstimatedTokens)
+		for _, issue := range report.Issues {
+			_, _ = fmt.Fprintln(out, issue.String())
+		}
+		_, _ = fmt.Fprintf(out, "%d errors, %d warnings\n", report.Count(core.LintError), report.Count(core.LintWarning))
+	}
+
+	if count := report.Count(core.LintError); count > 0 {
+		return fmt.Errorf("%s has %d errors", location, count)
+	}
+	return nil
+}
+
+// isConfigFile returns true if location is a local MakeMCP config file rather than a spec.
+func isConfigFile(location string) bool {
+	data, err := readConfigData(location)
+	if err != nil {
+		return false
+	}
+	var config struct {
+		SourceType string          `json:"sourceType"`
+		Tools      json.RawMessage `json:"tools"`
+	}
+	return json.Unmarshal(data, &config) == nil && config.SourceType != "" && config.Tools != nil
+}
+
+// handleMigrateCommand handles the migrate command upgrading config files to the current schema version.
+func handleMigrateCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return fmt.Errorf("migrate command requires at least one argument: the path to a config file")
+	}
+	check := cmd.Bool("check")
+
+	out := cmd.Root().Writer
+	outdated := 0
+	for _, configPath := range args {
+		migrations, err := migrateConfigFile(configPath, check)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", configPath, err)
+		}
+		if len(migrations) == 0 {
+			_, _ = fmt.Fprintf(out, "%s is up to date with schema version %d\n", configPath, core.CurrentSchemaVersion)
+			continue
+		}
+
+		outdated++
+		action := "migrated"
+		if check {
+			action = "needs migration"
+		}
+		_, _ = fmt.Fprintf(out, "%s %s from schema version %d to %d\n",
+			configPath, action, migrations[0].From, core.CurrentSchemaVersion)
+		for _, migration := range migrations {
+			_, _ = fmt.Fprintf(out, "  - %s\n", migration.Description)
+		}
+	}
+
+	if check && outdated > 0 {
+		return fmt.Errorf("%d of %d config files need to be migrated", outdated, len(args))
+	}
+	return nil
+}
+
+// migrateConfigFile rewrites a config file in the current format if it has an older schema version,
+// returning the applied migrations. References are kept unresolved, so no secrets are needed.
+func migrateConfigFile(configPath string, check bool) ([]core.ConfigMigration, error) {
+	data, source, migrations, err := readMigratedConfig(configPath)
+	if err != nil || len(migrations) == 0 || check {
+		return migrations, err
+	}
+	app, err := source.UnmarshalConfig(data, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	format := getConfigFormat(configPath)
+	if format == "" {
+		format = ConfigFormatJSON
+	}
+	return migrations, writeConfigFile(app, configPath, format)
+}
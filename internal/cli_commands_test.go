@@ -15,12 +15,16 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
 	"github.com/urfave/cli/v3"
 )
 
@@ -28,8 +32,12 @@ func TestGetInternalCommands(t *testing.T) {
 	commands := GetInternalCommands()
 
 	// Test that we get the expected number of commands
-	if len(commands) != 1 {
-		t.Errorf("Expected 1 internal command, got %d", len(commands))
+	if len(commands) != 4 {
+		t.Fatalf("Expected 4 internal commands, got %d", len(commands))
+	}
+	if commands[1].Name != "diff" || commands[2].Name != "validate" || commands[3].Name != "migrate" {
+		t.Errorf("Expected commands 'diff', 'validate' and 'migrate', got '%s', '%s' and '%s'",
+			commands[1].Name, commands[2].Name, commands[3].Name)
 	}
 
 	// Test the load command specifically
@@ -217,3 +225,417 @@ func TestHandleLoadCommand(t *testing.T) {
 	// an actual MCP server, which is not suitable for unit tests. In a real implementation,
 	// we would need to inject dependencies or make StartServer mockable.
 }
+
+func TestLoadWithOverrides(t *testing.T) {
+	InitializeRegistries()
+
+	configFile := filepath.Join(t.TempDir(), "makemcp.json")
+	configContent := `{
+		"name": "Test API",
+		"version": "1.0.0",
+		"sourceType": "openapi",
+		"tools": [],
+		"config": {
+			"transport": "stdio",
+			"port": "8080",
+			"type": "openapi",
+			"specs": "spec.json",
+			"baseUrl": "https://staging.example.com",
+			"timeout": 30,
+			"profile": "readonly",
+			"environments": {
+				"prod": {"baseURL": "https://prod.example.com", "timeout": 10}
+			}
+		}
+	}`
+	if err := os.WriteFile(configFile, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	load := func(args ...string) (*core.MakeMCPApp, error) {
+		var loaded *core.MakeMCPApp
+		app := &cli.Command{
+			Name: "makemcp",
+			Commands: []*cli.Command{
+				{
+					Name:  "load",
+					Flags: GetInternalCommands()[0].Flags,
+					Action: func(ctx context.Context, cmd *cli.Command) error {
+						var err error
+						loaded, err = loadWithOverrides(cmd, cmd.Args().First())
+						return err
+					},
+				},
+			},
+		}
+		err := app.Run(context.Background(), append(append([]string{"makemcp", "load"}, args...), configFile))
+		return loaded, err
+	}
+
+	t.Run("Without overrides", func(t *testing.T) {
+		app, err := load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		params := app.AppParams.(*openapi.OpenAPIParams)
+		if params.BaseURL != "https://staging.example.com" || params.Timeout != 30 {
+			t.Errorf("Expected saved params, got base URL %s and timeout %d", params.BaseURL, params.Timeout)
+		}
+		if params.Transport != core.TransportTypeStdio || params.Profile != "readonly" {
+			t.Errorf("Expected saved shared params, got transport %s and profile %s", params.Transport, params.Profile)
+		}
+	})
+
+	// Flags keep their values across runs, so subtests setting a flag run after those relying on its saved value
+	t.Run("With environment", func(t *testing.T) {
+		app, err := load("--env", "prod")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		params := app.AppParams.(*openapi.OpenAPIParams)
+		if params.BaseURL != "https://prod.example.com" || params.Timeout != 10 {
+			t.Errorf("Expected params of the prod environment, got base URL %s and timeout %d", params.BaseURL, params.Timeout)
+		}
+	})
+
+	t.Run("Environment from variable with overrides", func(t *testing.T) {
+		t.Setenv(core.EnvironmentVariable, "prod")
+		app, err := load("--timeout", "5")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		params := app.AppParams.(*openapi.OpenAPIParams)
+		if params.BaseURL != "https://prod.example.com" || params.Timeout != 5 {
+			t.Errorf("Expected flags to override the environment, got base URL %s and timeout %d", params.BaseURL, params.Timeout)
+		}
+	})
+
+	t.Run("Unknown environment", func(t *testing.T) {
+		if _, err := load("--env", "qa"); err == nil || !strings.Contains(err.Error(), "unknown environment") {
+			t.Errorf("Expected unknown environment error, got: %v", err)
+		}
+	})
+
+	t.Run("With overrides", func(t *testing.T) {
+		app, err := load(
+			"--base-url", "https://api.example.com",
+			"--timeout", "5",
+			"--transport", "http",
+			"--port", "9090",
+			"--profile", "all",
+		)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		params := app.AppParams.(*openapi.OpenAPIParams)
+		if params.BaseURL != "https://api.example.com" || params.Timeout != 5 {
+			t.Errorf("Expected overridden params, got base URL %s and timeout %d", params.BaseURL, params.Timeout)
+		}
+		if params.Transport != core.TransportTypeHTTP || params.Port != "9090" || params.Profile != "all" {
+			t.Errorf("Expected overridden shared params, got transport %s, port %s and profile %s",
+				params.Transport, params.Port, params.Profile)
+		}
+	})
+
+	t.Run("Invalid transport", func(t *testing.T) {
+		if _, err := load("--transport", "grpc"); err == nil || !strings.Contains(err.Error(), "invalid transport type") {
+			t.Errorf("Expected invalid transport error, got: %v", err)
+		}
+	})
+
+	t.Run("Invalid override", func(t *testing.T) {
+		if _, err := load("--timeout", "-1"); err == nil || !strings.Contains(err.Error(), "failed to apply openapi overrides") {
+			t.Errorf("Expected override error, got: %v", err)
+		}
+	})
+}
+
+func TestHandleDiffCommand(t *testing.T) {
+	InitializeRegistries()
+
+	tempDir := t.TempDir()
+	specFile := filepath.Join(tempDir, "spec.json")
+	writeSpec := func(getPetParams string) {
+		spec := `{
+			"openapi": "3.0.0",
+			"info": {"title": "Pets", "version": "1.0.0"},
+			"paths": {
+				"/pets/{id}": {
+					"get": {
+						"operationId": "getPet",
+						"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}` + getPetParams + `],
+						"responses": {"200": {"description": "ok"}}
+					}
+				}
+			}
+		}`
+		if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+			t.Fatalf("Failed to write spec: %v", err)
+		}
+	}
+	writeSpec("")
+
+	params := openapi.NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.Specs = specFile
+	params.BaseURL = "https://api.example.com"
+	params.DevMode = true
+	saved, err := openapi.NewOpenAPISource().Parse(params)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("Failed to encode config: %v", err)
+	}
+	configFile := filepath.Join(tempDir, "makemcp.json")
+	if err := os.WriteFile(configFile, data, 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	runDiff := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		app := &cli.Command{
+			Name:     "makemcp",
+			Writer:   &out,
+			Commands: []*cli.Command{&diffCommand},
+		}
+		err := app.Run(context.Background(), append([]string{"makemcp", "diff"}, args...))
+		return out.String(), err
+	}
+
+	output, err := runDiff(configFile)
+	if err != nil || !strings.Contains(output, "is up to date with the current spec") {
+		t.Errorf("Expected no drift, got %q (%v)", output, err)
+	}
+
+	// Only the spec location is resolved, other references need neither variables nor commands
+	t.Setenv("TEST_DIFF_SPEC", specFile)
+	marker := filepath.Join(tempDir, "diff-marker")
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Failed to decode config: %v", err)
+	}
+	config := document["config"].(map[string]any)
+	config["specs"] = "${TEST_DIFF_SPEC}"
+	config["headers"] = map[string]any{"Authorization": "${TEST_UNSET_TOKEN}", "X-Run": "exec:touch " + marker}
+	referenceFile := filepath.Join(tempDir, "references.json")
+	referenceData, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("Failed to encode config: %v", err)
+	}
+	if err := os.WriteFile(referenceFile, referenceData, 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	output, err = runDiff(referenceFile)
+	if err != nil || !strings.Contains(output, "is up to date with the current spec") {
+		t.Errorf("Expected spec location to be resolved, got %q (%v)", output, err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected diff not to run commands of the config")
+	}
+
+	writeSpec(`, {"name": "verbose", "in": "query", "schema": {"type": "boolean"}}`)
+	output, err = runDiff(configFile)
+	if err != nil || !strings.Contains(output, "1 changes, 0 breaking") || !strings.Contains(output, "getpet: optional parameter query__verbose was added") {
+		t.Errorf("Expected non-breaking drift, got %q (%v)", output, err)
+	}
+
+	writeSpec(`, {"name": "tenant", "in": "query", "required": true, "schema": {"type": "string"}}`)
+	output, err = runDiff("--json", configFile)
+	if err == nil || !strings.Contains(err.Error(), "has breaking changes") {
+		t.Errorf("Expected breaking changes to fail the command, got %v", err)
+	}
+	var changes []core.ToolChange
+	if jsonErr := json.Unmarshal([]byte(output), &changes); jsonErr != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output, jsonErr)
+	}
+	if len(changes) != 1 || changes[0].Kind != core.ParameterAdded || !changes[0].Breaking {
+		t.Errorf("Expected required parameter to be reported as breaking, got %+v", changes)
+	}
+
+	if _, err := runDiff(); err == nil || !strings.Contains(err.Error(), "requires exactly one argument") {
+		t.Errorf("Expected error for missing argument, got %v", err)
+	}
+}
+
+func TestHandleValidateCommand(t *testing.T) {
+	InitializeRegistries()
+	tempDir := t.TempDir()
+
+	runValidate := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		app := &cli.Command{
+			Name:     "makemcp",
+			Writer:   &out,
+			Commands: []*cli.Command{&validateCommand},
+		}
+		err := app.Run(context.Background(), append([]string{"makemcp", "validate"}, args...))
+		return out.String(), err
+	}
+
+	specFile := filepath.Join(tempDir, "spec.json")
+	spec := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"operationId": "listPets",
+					"parameters": [{"name": "page__size", "in": "query", "schema": {"type": "integer"}}],
+					"responses": {"200": {"description": "ok"}}
+				}
+			}
+		}
+	}`
+	if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	output, err := runValidate(specFile)
+	if err != nil {
+		t.Errorf("Expected warnings not to fail validation, got %v", err)
+	}
+	if !strings.Contains(output, "1 tools, about") || !strings.Contains(output, "listpets.query__page__size") || !strings.Contains(output, "0 errors, 1 warnings") {
+		t.Errorf("Unexpected report: %s", output)
+	}
+
+	configFile := filepath.Join(tempDir, "makemcp.json")
+	config := `{
+		"name": "Pets",
+		"version": "1.0.0",
+		"sourceType": "openapi",
+		"tools": [
+			{"name": "list pets", "description": "List pets", "inputSchema": {"type": "object"}},
+			{"name": "getpet", "inputSchema": {"type": "object"}}
+		],
+		"config": {"transport": "stdio", "specs": "spec.json", "baseURL": "https://api.example.com", "timeout": 30}
+	}`
+	if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	output, err = runValidate("--json", configFile)
+	if err == nil || !strings.Contains(err.Error(), "has 1 errors") {
+		t.Errorf("Expected invalid tool name to fail validation, got %v", err)
+	}
+	var report core.LintReport
+	if jsonErr := json.Unmarshal([]byte(output), &report); jsonErr != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output, jsonErr)
+	}
+	if report.Tools != 2 || len(report.Issues) != 2 || report.Issues[0].Rule != "invalid_name" || report.Issues[1].Rule != "missing_description" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	// References are not resolved, so unset variables do not fail validation and commands are not run
+	marker := filepath.Join(tempDir, "validate-marker")
+	referenceConfig := `{
+		"name": "Pets",
+		"version": "1.0.0",
+		"sourceType": "openapi",
+		"tools": [{"name": "listpets", "description": "List pets", "inputSchema": {"type": "object"}}],
+		"config": {"specs": "spec.json", "baseURL": "${TEST_UNSET_BASE_URL}", "timeout": 30,
+			"headers": {"X-Run": "exec:touch ` + marker + `"}}
+	}`
+	if err := os.WriteFile(configFile, []byte(referenceConfig), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if output, err := runValidate(configFile); err != nil || !strings.Contains(output, "0 errors") {
+		t.Errorf("Expected config with references to be valid, got %q (%v)", output, err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected validate not to run commands of the config")
+	}
+}
+
+func TestHandleMigrateCommand(t *testing.T) {
+	InitializeRegistries()
+
+	tempDir := t.TempDir()
+	legacyConfig := `{
+		"name": "Legacy API",
+		"version": "1.0.0",
+		"sourceType": "openapi",
+		"tools": [],
+		"config": {
+			"transport": "stdio",
+			"type": "openapi",
+			"specs": "spec.json",
+			"baseURL": "${LEGACY_BASE_URL}",
+			"timeout": 30,
+			"flags": {"specs": "spec.json"},
+			"args": []
+		}
+	}`
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+	runMigrate := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		app := &cli.Command{
+			Name:     "makemcp",
+			Writer:   &out,
+			Commands: []*cli.Command{&migrateCommand},
+		}
+		err := app.Run(context.Background(), append([]string{"makemcp", "migrate"}, args...))
+		return out.String(), err
+	}
+
+	jsonPath := writeConfig("legacy.json", legacyConfig)
+	yamlPath := writeConfig("legacy.yaml", "# Legacy API for the support team\nname: Legacy API\nsourceType: openapi\ntools: []\n"+
+		"config:\n  type: openapi\n  specs: spec.json\n  baseURL: https://api.example.com\n  timeout: 30\n  args: []\n")
+
+	output, err := runMigrate("--check", jsonPath, yamlPath)
+	if err == nil || !strings.Contains(err.Error(), "2 of 2 config files need to be migrated") {
+		t.Errorf("Expected check to fail for legacy configs, got %v", err)
+	}
+	if !strings.Contains(output, "legacy.json needs migration from schema version 0 to 1") {
+		t.Errorf("Expected legacy config to be reported, got %q", output)
+	}
+	if data, _ := os.ReadFile(jsonPath); string(data) != legacyConfig {
+		t.Error("Expected check not to rewrite the config")
+	}
+
+	output, err = runMigrate(jsonPath, yamlPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v (%s)", err, output)
+	}
+	if !strings.Contains(output, "legacy.json migrated from schema version 0 to 1") ||
+		!strings.Contains(output, "remove the raw CLI flags and arguments") {
+		t.Errorf("Expected migration to be reported, got %q", output)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+	migrated := string(data)
+	if !strings.Contains(migrated, `"schemaVersion": 1`) || strings.Contains(migrated, `"flags"`) {
+		t.Errorf("Expected versioned config without raw CLI input, got:\n%s", migrated)
+	}
+	if !strings.Contains(migrated, "${LEGACY_BASE_URL}") {
+		t.Errorf("Expected reference to be kept unresolved, got:\n%s", migrated)
+	}
+
+	data, err = os.ReadFile(yamlPath)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+	if !strings.Contains(string(data), "# Legacy API for the support team") || !strings.Contains(string(data), "schemaVersion: 1") {
+		t.Errorf("Expected versioned YAML config keeping its comments, got:\n%s", data)
+	}
+
+	output, err = runMigrate("--check", jsonPath, yamlPath)
+	if err != nil || !strings.Contains(output, "legacy.json is up to date with schema version 1") {
+		t.Errorf("Expected migrated configs to be up to date, got %q (%v)", output, err)
+	}
+
+	if _, err := runMigrate(writeConfig("future.json", `{"schemaVersion": 99, "sourceType": "openapi"}`)); err == nil ||
+		!strings.Contains(err.Error(), "unsupported schema version 99") {
+		t.Errorf("Expected error for newer schema version, got %v", err)
+	}
+	if _, err := runMigrate(); err == nil {
+		t.Error("Expected error without config files")
+	}
+}
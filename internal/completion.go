@@ -0,0 +1,119 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// completionTimeout bounds the lookups of a completion request. mcp-go answers completion
+// requests of the stdio transport while reading input, so slow lookups delay other requests.
+const completionTimeout = 5 * time.Second
+
+// CompletionHandler completes the arguments of prompts and the variables of resource templates
+// with the values suggested by their tools. It is the prompt and resource completion provider
+// of the MCP server.
+type CompletionHandler struct {
+	mu        sync.RWMutex
+	prompts   map[string]core.CompletionProvider // Prompt name to the tool completing its arguments
+	resources map[string]core.CompletionProvider // URI template to the tool completing its variables
+}
+
+// NewCompletionHandler creates a completion handler for app.
+// Returns nil if no prompt or resource template of app supports completion.
+func NewCompletionHandler(app *core.MakeMCPApp) *CompletionHandler {
+	handler := &CompletionHandler{}
+	if !handler.update(app) {
+		return nil
+	}
+	return handler
+}
+
+// update replaces the completed prompts and resource templates with those of app,
+// returning false if none of them supports completion.
+func (h *CompletionHandler) update(app *core.MakeMCPApp) bool {
+	prompts := make(map[string]core.CompletionProvider)
+	resources := make(map[string]core.CompletionProvider)
+	providers := make(map[string]core.CompletionProvider)
+	for _, tool := range app.Tools {
+		provider, ok := tool.(core.CompletionProvider)
+		if !ok {
+			continue
+		}
+		providers[tool.GetName()] = provider
+		if resourceProvider, ok := tool.(core.ResourceProvider); ok {
+			if resource, exposed := resourceProvider.ToMcpResource(); exposed && resource.IsTemplate {
+				resources[resource.URI] = provider
+			}
+		}
+	}
+	for _, prompt := range app.Prompts {
+		if provider, ok := providers[prompt.Tool]; ok && len(prompt.Arguments) > 0 {
+			prompts[prompt.Name] = provider
+		}
+	}
+
+	h.mu.Lock()
+	h.prompts, h.resources = prompts, resources
+	h.mu.Unlock()
+	return len(prompts) > 0 || len(resources) > 0
+}
+
+// CompletePromptArgument returns the values completing an argument of the named prompt.
+// Unknown prompts complete to no values.
+func (h *CompletionHandler) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	h.mu.RLock()
+	provider := h.prompts[promptName]
+	h.mu.RUnlock()
+	return complete(ctx, provider, argument)
+}
+
+// CompleteResourceArgument returns the values completing a variable of the resource template uri.
+// Unknown resource templates complete to no values.
+func (h *CompletionHandler) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	h.mu.RLock()
+	provider := h.resources[uri]
+	h.mu.RUnlock()
+	return complete(ctx, provider, argument)
+}
+
+// complete returns at most core.MaxCompletionValues values of provider completing argument.
+func complete(ctx context.Context, provider core.CompletionProvider, argument mcp.CompleteArgument) (*mcp.Completion, error) {
+	completion := &mcp.Completion{Values: []string{}}
+	if provider == nil {
+		return completion, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+	values, err := provider.CompleteArgument(ctx, argument.Name, argument.Value)
+	if err != nil {
+		return nil, err
+	}
+	completion.Total = len(values)
+	if len(values) > core.MaxCompletionValues {
+		values = values[:core.MaxCompletionValues]
+		completion.HasMore = true
+	}
+	if values != nil {
+		completion.Values = values
+	}
+	return completion, nil
+}
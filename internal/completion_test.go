@@ -0,0 +1,234 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mockCompletionTool is a mock resource template tool completing its arguments from a fixed list.
+type mockCompletionTool struct {
+	mockResourceTool
+}
+
+func (m *mockCompletionTool) CompleteArgument(ctx context.Context, argument, value string) ([]string, error) {
+	if argument == "fail" {
+		return nil, fmt.Errorf("lookup failed")
+	}
+	var values []string
+	for _, id := range []string{"alpha", "beta", "alpine"} {
+		if strings.HasPrefix(id, value) {
+			values = append(values, argument+":"+id)
+		}
+	}
+	return values, nil
+}
+
+// newCompletionApp returns an app with a completing resource template tool and a prompt calling it.
+func newCompletionApp() *core.MakeMCPApp {
+	return &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools:   []core.MakeMCPTool{&mockCompletionTool{}},
+		Prompts: []core.McpPrompt{
+			{Name: "get_user_example", Tool: "mock_tool", Arguments: []core.McpPromptArgument{{Name: "path__id"}}},
+			{Name: "overview"},
+		},
+	}
+}
+
+func TestNewCompletionHandler(t *testing.T) {
+	if handler := NewCompletionHandler(&core.MakeMCPApp{Tools: []core.MakeMCPTool{&mockMakeMCPTool{}}}); handler != nil {
+		t.Error("Expected no completion handler without completable prompts or resource templates")
+	}
+
+	handler := NewCompletionHandler(newCompletionApp())
+	if handler == nil {
+		t.Fatal("Expected completion handler")
+	}
+	if _, ok := handler.prompts["get_user_example"]; !ok {
+		t.Error("Expected prompt calling the tool to be completable")
+	}
+	if _, ok := handler.prompts["overview"]; ok {
+		t.Error("Expected prompt without arguments not to be completable")
+	}
+	if _, ok := handler.resources["https://api.example.com/users/{id}"]; !ok {
+		t.Error("Expected resource template to be completable")
+	}
+}
+
+func TestGetMCPServer_Completion(t *testing.T) {
+	mcpServer := GetMCPServer(newCompletionApp())
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "prompt argument",
+			message: `{"jsonrpc": "2.0", "id": 1, "method": "completion/complete", "params": {"ref": {"type": "ref/prompt", "name": "get_user_example"}, "argument": {"name": "path__id", "value": "al"}}}`,
+			want:    `"result":{"completion":{"values":["path__id:alpha","path__id:alpine"],"total":2}}`,
+		},
+		{
+			name:    "resource template variable",
+			message: `{"jsonrpc": "2.0", "id": "r", "method": "completion/complete", "params": {"ref": {"type": "ref/resource", "uri": "https://api.example.com/users/{id}"}, "argument": {"name": "id", "value": "b"}}}`,
+			want:    `"result":{"completion":{"values":["id:beta"],"total":1}}`,
+		},
+		{
+			name:    "unknown prompt",
+			message: `{"jsonrpc": "2.0", "id": 2, "method": "completion/complete", "params": {"ref": {"type": "ref/prompt", "name": "other"}, "argument": {"name": "x", "value": ""}}}`,
+			want:    `"result":{"completion":{"values":[]}}`,
+		},
+		{
+			name:    "failing lookup",
+			message: `{"jsonrpc": "2.0", "id": 3, "method": "completion/complete", "params": {"ref": {"type": "ref/prompt", "name": "get_user_example"}, "argument": {"name": "fail", "value": ""}}}`,
+			want:    `"message":"lookup failed"`,
+		},
+		{
+			name:    "initialize",
+			message: `{"jsonrpc": "2.0", "id": 4, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1.0"}}}`,
+			want:    `"completions":{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(tt.message)))
+			if err != nil {
+				t.Fatalf("Failed to encode response: %v", err)
+			}
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("Expected response containing %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestGetMCPServer_WithoutCompletion(t *testing.T) {
+	mcpServer := GetMCPServer(&core.MakeMCPApp{Name: "Test Server", Version: "1.0.0", Tools: []core.MakeMCPTool{&mockMakeMCPTool{}}})
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	initialize := send(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1.0"}}}`)
+	if strings.Contains(initialize, `"completions"`) {
+		t.Errorf("Expected no completions capability, got %s", initialize)
+	}
+	completion := send(`{"jsonrpc": "2.0", "id": 2, "method": "completion/complete", "params": {"ref": {"type": "ref/prompt", "name": "other"}, "argument": {"name": "x", "value": ""}}}`)
+	if !strings.Contains(completion, `"error"`) {
+		t.Errorf("Expected completion requests to fail, got %s", completion)
+	}
+}
+
+func TestCompletionHandler_Stdio(t *testing.T) {
+	mcpServer := GetMCPServer(newCompletionApp())
+
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = server.NewStdioServer(mcpServer).Listen(ctx, stdinReader, stdoutWriter)
+	}()
+
+	lines := bufio.NewReader(stdoutReader)
+	exchange := func(message string) string {
+		if _, err := stdinWriter.Write([]byte(message + "\n")); err != nil {
+			t.Fatalf("Failed to write message: %v", err)
+		}
+		result := make(chan string, 1)
+		go func() {
+			line, _ := lines.ReadString('\n')
+			result <- line
+		}()
+		select {
+		case line := <-result:
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for response to %s", message)
+			return ""
+		}
+	}
+
+	initialize := exchange(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1.0"}}}`)
+	if !strings.Contains(initialize, `"completions":{}`) {
+		t.Errorf("Expected completions capability in initialize response, got %s", initialize)
+	}
+
+	completion := exchange(`{"jsonrpc": "2.0", "id": 2, "method": "completion/complete", "params": {"ref": {"type": "ref/resource", "uri": "https://api.example.com/users/{id}"}, "argument": {"name": "id", "value": "be"}}}`)
+	if !strings.Contains(completion, `"values":["id:beta"]`) {
+		t.Errorf("Expected completion values, got %s", completion)
+	}
+
+	tools := exchange(`{"jsonrpc": "2.0", "id": 3, "method": "tools/list"}`)
+	if !strings.Contains(tools, `"mock_tool"`) {
+		t.Errorf("Expected other requests to be answered, got %s", tools)
+	}
+}
+
+func TestCompletionHandler_HTTP(t *testing.T) {
+	httpServer := httptest.NewServer(server.NewStreamableHTTPServer(GetMCPServer(newCompletionApp())))
+	defer httpServer.Close()
+
+	post := func(message, sessionID string) (string, *http.Response) {
+		request, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(message))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if sessionID != "" {
+			request.Header.Set(server.HeaderKeySessionID, sessionID)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Failed to post message: %v", err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return string(body), response
+	}
+
+	initialize, response := post(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1.0"}}}`, "")
+	if !strings.Contains(initialize, `"completions":{}`) {
+		t.Errorf("Expected completions capability in initialize response, got %s", initialize)
+	}
+	sessionID := response.Header.Get(server.HeaderKeySessionID)
+
+	message := `{"jsonrpc": "2.0", "id": 2, "method": "completion/complete", "params": {"ref": {"type": "ref/prompt", "name": "get_user_example"}, "argument": {"name": "path__id", "value": "alp"}}}`
+	if _, response := post(message, "invalid-session"); response.StatusCode == http.StatusOK {
+		t.Errorf("Expected completion requests of unknown sessions to be rejected, got HTTP %d", response.StatusCode)
+	}
+	if completion, _ := post(message, sessionID); !strings.Contains(completion, `"values":["path__id:alpha","path__id:alpine"]`) {
+		t.Errorf("Expected completion values, got %s", completion)
+	}
+}
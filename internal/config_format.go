@@ -0,0 +1,226 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Config file formats supported by SaveToFile and LoadFromFile.
+const (
+	ConfigFormatJSON = "json"
+	ConfigFormatYAML = "yaml"
+)
+
+// getConfigFormat returns the format of a config file by its extension, or "" if the extension is unknown.
+func getConfigFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return ConfigFormatJSON
+	case ".yaml", ".yml":
+		return ConfigFormatYAML
+	default:
+		return ""
+	}
+}
+
+// getConfigFilename returns the filename and format app is saved with.
+// The format is taken from the extension of the file parameter if it has one, else from the format parameter.
+func getConfigFilename(app *core.MakeMCPApp) (string, string, error) {
+	sharedParams := app.AppParams.GetSharedParams()
+	if format := getConfigFormat(sharedParams.File); format != "" {
+		return sharedParams.File, format, nil
+	}
+
+	format := sharedParams.Format
+	if format == "" {
+		format = ConfigFormatJSON
+	}
+	if format != ConfigFormatJSON && format != ConfigFormatYAML {
+		return "", "", fmt.Errorf("invalid config file format %s, must be %s or %s", format, ConfigFormatJSON, ConfigFormatYAML)
+	}
+	if sharedParams.File != "" {
+		return fmt.Sprintf("%s.%s", sharedParams.File, format), format, nil
+	}
+	return fmt.Sprintf("%s_makemcp.%s", app.Name, format), format, nil
+}
+
+// encodeConfig serializes app in the given format, saving values resolved from references as the references.
+// YAML keeps the comments of previous, the content of the file being replaced, where the same keys still exist.
+func encodeConfig(app *core.MakeMCPApp, format string, previous []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(app); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	if format == ConfigFormatJSON && len(app.References) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	// JSON is valid YAML, so decoding it keeps the order of the fields
+	var root yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &root); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	restoreReferences(&root, app.References)
+	if format == ConfigFormatJSON {
+		compact, err := encodeNodeJSON(&root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		buf.Reset()
+		if err := json.Indent(&buf, compact, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+
+	resetStyle(&root)
+	var previousRoot yaml.Node
+	if len(previous) > 0 && yaml.Unmarshal(previous, &previousRoot) == nil {
+		copyComments(&root, &previousRoot)
+	}
+
+	buf.Reset()
+	yamlEncoder := yaml.NewEncoder(&buf)
+	yamlEncoder.SetIndent(2)
+	if err := yamlEncoder.Encode(&root); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := yamlEncoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeConfig converts the content of a config file in the given format to JSON.
+func decodeConfig(data []byte, format string) ([]byte, error) {
+	if format != ConfigFormatYAML {
+		return data, nil
+	}
+	var value any
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode YAML: %w", err)
+	}
+	converted, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert YAML to JSON: %w", err)
+	}
+	return converted, nil
+}
+
+// readConfigData reads a config file and returns its content as JSON, detecting YAML by the file extension.
+func readConfigData(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Failed to close file: %v", err)
+		}
+	}()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return decodeConfig(data, getConfigFormat(filename))
+}
+
+// resetStyle switches nodes decoded from JSON to the block style of YAML,
+// strings are quoted again by the encoder where needed.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// copyComments copies the comments of src to the nodes of dst at the same place.
+// Mapping values are matched by key, sequence items by their name field or else by index.
+func copyComments(dst, src *yaml.Node) {
+	if dst.Kind != src.Kind {
+		return
+	}
+	dst.HeadComment = src.HeadComment
+	dst.LineComment = src.LineComment
+	dst.FootComment = src.FootComment
+
+	switch dst.Kind {
+	case yaml.DocumentNode:
+		if len(dst.Content) > 0 && len(src.Content) > 0 {
+			copyComments(dst.Content[0], src.Content[0])
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			for j := 0; j+1 < len(src.Content); j += 2 {
+				if dst.Content[i].Value == src.Content[j].Value {
+					copyComments(dst.Content[i], src.Content[j])
+					copyComments(dst.Content[i+1], src.Content[j+1])
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range dst.Content {
+			if match := findSequenceItem(src, item, i); match != nil {
+				copyComments(item, match)
+			}
+		}
+	}
+}
+
+// findSequenceItem returns the item of sequence matching item by its name field, or the item at index
+// if item has no name, so comments stay with their tool when tools are added or removed.
+func findSequenceItem(sequence *yaml.Node, item *yaml.Node, index int) *yaml.Node {
+	if name := getNameField(item); name != "" {
+		for _, candidate := range sequence.Content {
+			if getNameField(candidate) == name {
+				return candidate
+			}
+		}
+		return nil
+	}
+	if index < len(sequence.Content) {
+		return sequence.Content[index]
+	}
+	return nil
+}
+
+// getNameField returns the value of the name field of a mapping node, or "" if it has none.
+func getNameField(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "name" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
+)
+
+// newFormatTestApp returns an app saved to file with the given format parameter.
+func newFormatTestApp(file, format string) *core.MakeMCPApp {
+	params := openapi.NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.File = file
+	params.Format = format
+	params.Specs = "http://example.com/openapi.yaml"
+	params.BaseURL = "true"
+	return &core.MakeMCPApp{
+		Name:       "yaml-test",
+		Version:    "1.0",
+		SourceType: "openapi",
+		AppParams:  params,
+		Tools: []core.MakeMCPTool{&openapi.OpenAPIMcpTool{
+			McpTool: core.McpTool{Name: "listpets", Description: "List pets"},
+		}},
+	}
+}
+
+func TestGetConfigFilename(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		format         string
+		expectFilename string
+		expectFormat   string
+		expectError    bool
+	}{
+		{"Default format", "config", "", "config.json", ConfigFormatJSON, false},
+		{"YAML format", "config", "yaml", "config.yaml", ConfigFormatYAML, false},
+		{"JSON extension", "config.json", "yaml", "config.json", ConfigFormatJSON, false},
+		{"YAML extension", "config.yaml", "", "config.yaml", ConfigFormatYAML, false},
+		{"YML extension", "dir/config.YML", "json", "dir/config.YML", ConfigFormatYAML, false},
+		{"Default filename", "", "yaml", "yaml-test_makemcp.yaml", ConfigFormatYAML, false},
+		{"Invalid format", "config", "toml", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename, format, err := getConfigFilename(newFormatTestApp(tt.file, tt.format))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if filename != tt.expectFilename || format != tt.expectFormat {
+				t.Errorf("Expected %s as %s, got %s as %s", tt.expectFilename, tt.expectFormat, filename, format)
+			}
+		})
+	}
+}
+
+func TestSaveAndLoadYAML(t *testing.T) {
+	InitializeRegistries()
+
+	file := filepath.Join(t.TempDir(), "makemcp")
+	app := newFormatTestApp(file, ConfigFormatYAML)
+	if err := SaveToFile(app); err != nil {
+		t.Fatalf("Failed to save app: %v", err)
+	}
+
+	data, err := os.ReadFile(file + ".yaml")
+	if err != nil {
+		t.Fatalf("Expected YAML config file: %v", err)
+	}
+	if strings.HasPrefix(string(data), "{") || !strings.Contains(string(data), "name: yaml-test") {
+		t.Errorf("Expected block style YAML, got:\n%s", data)
+	}
+
+	loaded, err := LoadFromFile(file + ".yaml")
+	if err != nil {
+		t.Fatalf("Failed to load YAML config: %v", err)
+	}
+	params := loaded.AppParams.(*openapi.OpenAPIParams)
+	// Strings looking like other types must stay strings
+	if loaded.Version != "1.0" || params.Port != "8080" || params.BaseURL != "true" {
+		t.Errorf("Expected string values to round-trip, got version %q, port %q and base URL %q",
+			loaded.Version, params.Port, params.BaseURL)
+	}
+	if len(loaded.Tools) != 1 || loaded.Tools[0].GetName() != "listpets" {
+		t.Errorf("Expected tool listpets, got %v", loaded.Tools)
+	}
+	if !isConfigFile(file + ".yaml") {
+		t.Error("Expected YAML config to be detected as config file")
+	}
+}
+
+func TestSaveToFile_PreservesYAMLComments(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "makemcp.yaml")
+	edited := `# Pets API for the support team
+name: yaml-test
+tools:
+  - name: removed
+    # dropped from the spec
+    description: Removed
+  - name: listpets # keep this one
+    description: List pets
+config:
+  # production API
+  baseURL: https://api.example.com
+`
+	if err := os.WriteFile(filename, []byte(edited), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if err := SaveToFile(newFormatTestApp(filename, "")); err != nil {
+		t.Fatalf("Failed to save app: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	for _, comment := range []string{"# Pets API for the support team", "# keep this one", "# production API"} {
+		if !strings.Contains(string(data), comment) {
+			t.Errorf("Expected comment %q to be preserved, got:\n%s", comment, data)
+		}
+	}
+	if strings.Contains(string(data), "# dropped from the spec") {
+		t.Errorf("Expected comment of the removed tool to be dropped, got:\n%s", data)
+	}
+}
+
+func TestDecodeConfig_InvalidYAML(t *testing.T) {
+	if _, err := decodeConfig([]byte("name: [unclosed"), ConfigFormatYAML); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+	data := []byte(`{"name": "json"}`)
+	if decoded, err := decodeConfig(data, ConfigFormatJSON); err != nil || string(decoded) != string(data) {
+		t.Errorf("Expected JSON to be returned unchanged, got %s (%v)", decoded, err)
+	}
+}
@@ -17,7 +17,6 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -26,16 +25,20 @@ import (
 	"github.com/T4cceptor/MakeMCP/pkg/sources"
 )
 
-// SaveToFile serializes the given MakeMCPApp as JSON and writes it to a file.
-// The filename is derived from the file parameter or defaults to app name (e.g., "makemcp.json")
+// SaveToFile serializes the given MakeMCPApp as JSON or YAML and writes it to a file.
+// The filename is derived from the file parameter or defaults to app name (e.g., "makemcp.json"),
+// the format from the extension of the file parameter or the format parameter.
 func SaveToFile(app *core.MakeMCPApp) error {
-	var filename string
-	sharedParams := app.AppParams.GetSharedParams()
-	if sharedParams.File != "" {
-		filename = fmt.Sprintf("%s.json", sharedParams.File)
-	} else {
-		filename = fmt.Sprintf("%s_makemcp.json", app.Name)
+	filename, format, err := getConfigFilename(app)
+	if err != nil {
+		return err
 	}
+	return writeConfigFile(app, filename, format)
+}
+
+// writeConfigFile writes app to filename in the given format, always as the current schema version.
+func writeConfigFile(app *core.MakeMCPApp, filename string, format string) error {
+	app.SchemaVersion = core.CurrentSchemaVersion
 
 	// Ensure the directory exists
 	dir := filepath.Dir(filename)
@@ -45,6 +48,23 @@ func SaveToFile(app *core.MakeMCPApp) error {
 		}
 	}
 
+	// Keep the comments of a YAML config file that is replaced
+	var previous []byte
+	if format == ConfigFormatYAML {
+		previous, _ = os.ReadFile(filename)
+	}
+	// Save the params as read from the config file, not the environment selected when loading it
+	saved := app
+	if app.FileParams != nil {
+		copied := *app
+		copied.AppParams = app.FileParams
+		saved = &copied
+	}
+	data, err := encodeConfig(saved, format, previous)
+	if err != nil {
+		return err
+	}
+
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
@@ -55,10 +75,8 @@ func SaveToFile(app *core.MakeMCPApp) error {
 		}
 	}()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(app); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	// Get absolute path for logging
@@ -71,22 +89,88 @@ func SaveToFile(app *core.MakeMCPApp) error {
 	return nil
 }
 
-// LoadFromFile loads a MakeMCPApp from a JSON file.
+// LoadFromFile loads a MakeMCPApp from a JSON or YAML file.
 func LoadFromFile(filename string) (*core.MakeMCPApp, error) {
-	file, err := os.Open(filename)
+	app, source, err := ReadConfigFile(filename, core.SelectedEnvironment(""))
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Printf("Failed to close file: %v", err)
+
+	if err := source.AttachToolHandlers(app); err != nil {
+		return nil, fmt.Errorf("failed to attach tool handlers: %w", err)
+	}
+
+	log.Printf("MakeMCPApp loaded from %s\n", filename)
+	return app, nil
+}
+
+// ReadConfigFile reads a MakeMCPApp from a JSON or YAML file without attaching tool handlers,
+// returning it together with the source it was created by.
+// If environment is not empty, the named environment of the config file is merged into a copy of its params
+// used at runtime, while the params as read from the file are kept for saving it.
+// References to environment variables, files and commands are resolved in the source params
+// and the headers, cookies and appended body fields of tools.
+func ReadConfigFile(filename string, environment string) (*core.MakeMCPApp, sources.MakeMCPSource, error) {
+	return readConfigFile(filename, environment, isInterpolated)
+}
+
+// readConfigFile reads a config file like ReadConfigFile, resolving only the references at the paths
+// selected by resolve, none if it is nil, so configs can be checked without their secrets.
+func readConfigFile(
+	filename string, environment string, resolve func(path []string) bool,
+) (*core.MakeMCPApp, sources.MakeMCPSource, error) {
+	data, source, migrations, err := readMigratedConfig(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(migrations) > 0 {
+		log.Printf("Migrated configuration %s to schema version %d, run 'makemcp migrate %s' to update the file",
+			filename, core.CurrentSchemaVersion, filename)
+	}
+
+	// Resolve ${VAR}, file: and exec: references, remembering them for SaveToFile
+	var references []core.ConfigReference
+	if resolve != nil {
+		data, references, err = resolveReferences(data, resolve)
+		if err != nil {
+			return nil, nil, err
 		}
-	}()
+	}
+
+	// Use source's UnmarshalConfig method directly
+	// Note: Sources need to implement UnmarshalConfig method
+	app, err := source.UnmarshalConfig(data, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if environment != "" {
+		selected, err := source.UnmarshalConfig(data, environment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+		app.FileParams = app.AppParams
+		app.AppParams = selected.AppParams
+	}
+	app.References = references
+	return app, source, nil
+}
+
+// readMigratedConfig reads a config file as JSON upgraded to the current schema version,
+// returning it together with its source and the applied migrations.
+func readMigratedConfig(filename string) ([]byte, sources.MakeMCPSource, []core.ConfigMigration, error) {
+	// Read all data first, YAML is converted to JSON
+	data, err := readConfigData(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	// Read all data first
-	data, err := io.ReadAll(file)
+	// Upgrade configs written by older versions to the current format
+	if _, err := core.GetSchemaVersion(data); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	data, migrations, err := core.MigrateConfig(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, nil, nil, err
 	}
 
 	// Parse just the metadata to get source type
@@ -94,26 +178,13 @@ func LoadFromFile(filename string) (*core.MakeMCPApp, error) {
 		SourceType string `json:"sourceType"`
 	}
 	if err := json.Unmarshal(data, &metadata); err != nil {
-		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to decode metadata: %w", err)
 	}
 
 	// Get source from registry
 	source := sources.SourcesRegistry.Get(metadata.SourceType)
 	if source == nil {
-		return nil, fmt.Errorf("unknown source type: %s", metadata.SourceType)
+		return nil, nil, nil, fmt.Errorf("unknown source type: %s", metadata.SourceType)
 	}
-
-	// Use source's UnmarshalConfig method directly
-	// Note: Sources need to implement UnmarshalConfig method
-	app, err := source.UnmarshalConfig(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
-
-	if err := source.AttachToolHandlers(app); err != nil {
-		return nil, fmt.Errorf("failed to attach tool handlers: %w", err)
-	}
-
-	log.Printf("MakeMCPApp loaded from %s\n", filename)
-	return app, nil
+	return data, source, migrations, nil
 }
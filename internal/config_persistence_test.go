@@ -17,6 +17,7 @@ package internal
 import (
 	"encoding/json"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -408,6 +409,11 @@ func TestSaveAndLoadRoundTrip(t *testing.T) {
 			Timeout: 30,
 		},
 		Tools: []core.MakeMCPTool{},
+		Prompts: []core.McpPrompt{{
+			Name:      "users_overview",
+			Arguments: []core.McpPromptArgument{{Name: "task", Required: true}},
+			Messages:  []core.McpPromptMessage{{Role: "user", Content: "Use the users tools to {{task}}"}},
+		}},
 	}
 
 	filename := "roundtrip.json"
@@ -441,4 +447,7 @@ func TestSaveAndLoadRoundTrip(t *testing.T) {
 	if len(loadedApp.Tools) != len(originalApp.Tools) {
 		t.Errorf("Tools count mismatch: got %d, want %d", len(loadedApp.Tools), len(originalApp.Tools))
 	}
+	if !reflect.DeepEqual(loadedApp.Prompts, originalApp.Prompts) {
+		t.Errorf("Prompts mismatch: got %+v, want %+v", loadedApp.Prompts, originalApp.Prompts)
+	}
 }
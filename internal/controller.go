@@ -58,7 +58,22 @@ var defaultFlags []cli.Flag = []cli.Flag{
 		Name:    "file",
 		Aliases: []string{"f"},
 		Value:   "makemcp",
-		Usage:   "Filename (without extension) for the config file that will be saved as <filename>.json",
+		Usage:   "Filename for the config file that will be saved as <filename>.json or <filename>.yaml, keeping a .json, .yaml or .yml extension if given",
+	},
+	&cli.StringFlag{
+		Name:  "format",
+		Value: "json",
+		Usage: "Format of the config file - can be either json or yaml, ignored if the filename has an extension.",
+	},
+	&cli.BoolFlag{
+		Name:  "discovery",
+		Value: false,
+		Usage: "Advertise only the search_operations, describe_operation and invoke_operation meta-tools instead of every tool, for APIs with too many operations to list.",
+	},
+	&cli.BoolFlag{
+		Name:  "watch",
+		Value: false,
+		Usage: "Watch the spec or config files (polling remote URLs) and update the tools, resources and prompts of the running server when they change.",
 	},
 }
 
@@ -104,6 +119,9 @@ func GetInputConfig(source sources.MakeMCPSource, cmd *cli.Command) *core.CLIPar
 	sharedParams.Port = cmd.String("port")
 	sharedParams.DevMode = cmd.Bool("dev-mode")
 	sharedParams.File = cmd.String("file")
+	sharedParams.Format = cmd.String("format")
+	sharedParams.Discovery = cmd.Bool("discovery")
+	sharedParams.Watch = cmd.Bool("watch")
 
 	return &core.CLIParamsInput{
 		SharedParams: sharedParams,
@@ -144,6 +162,27 @@ func HandleInput(source sources.MakeMCPSource, inputParams *core.CLIParamsInput)
 		return fmt.Errorf("failed to attach tool handlers: %w", err)
 	}
 
-	// Start server
+	// Start server, reloading the app on changes in watch mode
+	if appParams.GetSharedParams().Watch {
+		watcher := NewWatcher(GetWatchLocations(appParams), func() (*core.MakeMCPApp, error) {
+			return reloadFromSource(source, appParams)
+		})
+		return StartWatchedServer(app, watcher)
+	}
 	return StartServer(app)
 }
+
+// reloadFromSource creates the app from params again, saving its configuration.
+func reloadFromSource(source sources.MakeMCPSource, params core.AppParams) (*core.MakeMCPApp, error) {
+	app, err := source.Parse(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse with %s source: %w", source.Name(), err)
+	}
+	if err := SaveToFile(app); err != nil {
+		return nil, fmt.Errorf("failed to save configuration: %w", err)
+	}
+	if err := source.AttachToolHandlers(app); err != nil {
+		return nil, fmt.Errorf("failed to attach tool handlers: %w", err)
+	}
+	return app, nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// Discovery mode advertises three meta-tools instead of every tool, so APIs with thousands of
+// operations do not flood the client's context. The model searches the tool index, reads the
+// schema of the operations it needs and invokes them by name.
+
+const (
+	searchOperationsTool  = "search_operations"
+	describeOperationTool = "describe_operation"
+	invokeOperationTool   = "invoke_operation"
+
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	maxSummaryLength   = 200
+)
+
+// toolIndex holds the tools available in discovery mode, keyed by name.
+type toolIndex struct {
+	tools  []core.MakeMCPTool
+	byName map[string]core.MakeMCPTool
+}
+
+// newToolIndex creates an index of tools.
+func newToolIndex(tools []core.MakeMCPTool) *toolIndex {
+	index := &toolIndex{
+		tools:  tools,
+		byName: make(map[string]core.MakeMCPTool, len(tools)),
+	}
+	for _, tool := range tools {
+		index.byName[tool.GetName()] = tool
+	}
+	return index
+}
+
+// operationSummary is a search result, the full schema is returned by describe_operation.
+type operationSummary struct {
+	Name    string   `json:"name"`
+	Summary string   `json:"summary,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// search returns the tools matching all keywords of query and, if set, tag, best matches first.
+// Keywords found in the name rank higher than keywords found in tags or the description.
+func (i *toolIndex) search(query, tag string) []operationSummary {
+	keywords := strings.Fields(strings.ToLower(query))
+	type match struct {
+		summary operationSummary
+		score   int
+	}
+	var matches []match
+	for _, tool := range i.tools {
+		var tags []string
+		if tagged, ok := tool.(core.TaggedTool); ok {
+			tags = tagged.GetTags()
+		}
+		if tag != "" && !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+
+		mcpTool := tool.ToMcpTool()
+		name := strings.ToLower(mcpTool.Name)
+		description := strings.ToLower(mcpTool.Description)
+		tagText := strings.ToLower(strings.Join(tags, " "))
+		score := 0
+		for _, keyword := range keywords {
+			keywordScore := 0
+			if strings.Contains(name, keyword) {
+				keywordScore += 3
+			}
+			if strings.Contains(tagText, keyword) {
+				keywordScore += 2
+			}
+			if strings.Contains(description, keyword) {
+				keywordScore++
+			}
+			if keywordScore == 0 {
+				score = -1
+				break
+			}
+			score += keywordScore
+		}
+		if score < 0 {
+			continue
+		}
+		matches = append(matches, match{
+			summary: operationSummary{Name: mcpTool.Name, Summary: summarize(mcpTool.Description), Tags: tags},
+			score:   score,
+		})
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return matches[a].summary.Name < matches[b].summary.Name
+	})
+	results := make([]operationSummary, len(matches))
+	for j, m := range matches {
+		results[j] = m.summary
+	}
+	return results
+}
+
+// summarize returns the first line of a description, shortened to maxSummaryLength characters.
+func summarize(description string) string {
+	summary, _, _ := strings.Cut(strings.TrimSpace(description), "\n")
+	if runes := []rune(summary); len(runes) > maxSummaryLength {
+		summary = string(runes[:maxSummaryLength]) + "..."
+	}
+	return summary
+}
+
+// lookup returns the tool with the given name, or an error pointing the model to search_operations.
+func (i *toolIndex) lookup(name string) (core.MakeMCPTool, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	tool, ok := i.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %s, use %s to find available operations", name, searchOperationsTool)
+	}
+	return tool, nil
+}
+
+// newDiscoveryTools returns the meta-tools giving access to tools through an in-memory index.
+func newDiscoveryTools(tools []core.MakeMCPTool) []core.MakeMCPTool {
+	index := newToolIndex(tools)
+	readOnly := true
+	openWorld := true
+	return []core.MakeMCPTool{
+		&builtinTool{
+			tool: core.McpTool{
+				Name:        searchOperationsTool,
+				Description: fmt.Sprintf("Search the %d available operations by keywords matched against their names, descriptions and tags. Returns operation names and summaries, use %s to get the parameters of an operation.", len(tools), describeOperationTool),
+				InputSchema: core.McpToolInputSchema{
+					Type: "object",
+					Properties: map[string]any{
+						"query": map[string]any{"type": "string", "description": "Space-separated keywords, all of which must match (e.g. 'list buckets')"},
+						"tag":   map[string]any{"type": "string", "description": "Only return operations with this tag"},
+						"limit": map[string]any{"type": "integer", "description": fmt.Sprintf("Maximum number of results (default %d, max %d)", defaultSearchLimit, maxSearchLimit)},
+					},
+				},
+				Annotations: core.McpToolAnnotation{Title: "Search operations", ReadOnlyHint: &readOnly},
+			},
+			handler: index.handleSearch,
+		},
+		&builtinTool{
+			tool: core.McpTool{
+				Name:        describeOperationTool,
+				Description: fmt.Sprintf("Get the full description and input schema of an operation, to build the arguments of %s.", invokeOperationTool),
+				InputSchema: core.McpToolInputSchema{
+					Type: "object",
+					Properties: map[string]any{
+						"name": map[string]any{"type": "string", "description": "Operation name as returned by " + searchOperationsTool},
+					},
+					Required: []string{"name"},
+				},
+				Annotations: core.McpToolAnnotation{Title: "Describe operation", ReadOnlyHint: &readOnly},
+			},
+			handler: index.handleDescribe,
+		},
+		&builtinTool{
+			tool: core.McpTool{
+				Name:        invokeOperationTool,
+				Description: fmt.Sprintf("Invoke an operation with arguments matching the input schema returned by %s.", describeOperationTool),
+				InputSchema: core.McpToolInputSchema{
+					Type: "object",
+					Properties: map[string]any{
+						"name":      map[string]any{"type": "string", "description": "Operation name as returned by " + searchOperationsTool},
+						"arguments": map[string]any{"type": "object", "description": "Arguments of the operation"},
+					},
+					Required: []string{"name"},
+				},
+				Annotations: core.McpToolAnnotation{Title: "Invoke operation", OpenWorldHint: &openWorld},
+			},
+			handler: index.handleInvoke,
+		},
+	}
+}
+
+// handleSearch implements search_operations.
+func (i *toolIndex) handleSearch(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+	params := request.GetParameters()
+	query, _ := params["query"].(string)
+	tag, _ := params["tag"].(string)
+	limit := defaultSearchLimit
+	if value, ok := params["limit"].(float64); ok && value > 0 {
+		limit = min(int(value), maxSearchLimit)
+	}
+
+	results := i.search(query, tag)
+	response := map[string]any{
+		"total":      len(results),
+		"operations": results[:min(limit, len(results))],
+	}
+	return jsonResult(response)
+}
+
+// handleDescribe implements describe_operation.
+func (i *toolIndex) handleDescribe(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+	name, _ := request.GetParameters()["name"].(string)
+	tool, err := i.lookup(name)
+	if err != nil {
+		return core.NewBasicExecutionError(err), nil
+	}
+	return jsonResult(tool.ToMcpTool())
+}
+
+// handleInvoke implements invoke_operation by forwarding the arguments to the tool's handler.
+func (i *toolIndex) handleInvoke(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+	params := request.GetParameters()
+	name, _ := params["name"].(string)
+	tool, err := i.lookup(name)
+	if err != nil {
+		return core.NewBasicExecutionError(err), nil
+	}
+
+	arguments := map[string]any{}
+	switch value := params["arguments"].(type) {
+	case map[string]any:
+		arguments = value
+	case string:
+		// Some clients send nested objects as JSON strings
+		if err := json.Unmarshal([]byte(value), &arguments); err != nil {
+			return core.NewBasicExecutionError(fmt.Errorf("arguments must be an object: %w", err)), nil
+		}
+	case nil:
+	default:
+		return core.NewBasicExecutionError(fmt.Errorf("arguments must be an object, got %T", value)), nil
+	}
+
+	handler := tool.GetHandler()
+	if handler == nil {
+		return core.NewBasicExecutionError(fmt.Errorf("operation %s has no handler", name)), nil
+	}
+	return handler(ctx, &invocationContext{ToolExecutionContext: request, toolName: name, parameters: arguments})
+}
+
+// jsonResult returns value as indented JSON content.
+func jsonResult(value any) (core.ToolExecutionResult, error) {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return core.NewBasicExecutionResult(string(content), nil), nil
+}
+
+// invocationContext is the execution context of a tool invoked through invoke_operation.
+// Metadata and progress notifications are shared with the invoke_operation call.
+type invocationContext struct {
+	core.ToolExecutionContext
+	toolName   string
+	parameters map[string]any
+}
+
+// GetToolName returns the name of the invoked tool.
+func (c *invocationContext) GetToolName() string { return c.toolName }
+
+// GetParameters returns the arguments of the invoked tool.
+func (c *invocationContext) GetParameters() map[string]any { return c.parameters }
+
+// builtinTool is a tool provided by MakeMCP itself, e.g. a meta-tool of discovery mode.
+type builtinTool struct {
+	tool    core.McpTool
+	handler core.MakeMcpToolHandler
+}
+
+// GetName returns the name of the built-in tool.
+func (b *builtinTool) GetName() string { return b.tool.Name }
+
+// GetHandler returns the handler of the built-in tool.
+func (b *builtinTool) GetHandler() core.MakeMcpToolHandler { return b.handler }
+
+// ToMcpTool returns the MCP definition of the built-in tool.
+func (b *builtinTool) ToMcpTool() core.McpTool { return b.tool }
+
+// isDiscoveryMode returns true if app advertises discovery meta-tools instead of its tools.
+func isDiscoveryMode(app *core.MakeMCPApp) bool {
+	if app.AppParams == nil {
+		return false
+	}
+	shared := app.AppParams.GetSharedParams()
+	return shared != nil && shared.Discovery
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
+)
+
+// mockIndexedTool is a mock tool with tags, echoing its arguments.
+type mockIndexedTool struct {
+	name        string
+	description string
+	tags        []string
+}
+
+func (m *mockIndexedTool) GetName() string { return m.name }
+
+func (m *mockIndexedTool) GetTags() []string { return m.tags }
+
+func (m *mockIndexedTool) ToMcpTool() core.McpTool {
+	return core.McpTool{Name: m.name, Description: m.description, InputSchema: core.McpToolInputSchema{Type: "object"}}
+}
+
+func (m *mockIndexedTool) GetHandler() core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		arguments, _ := json.Marshal(request.GetParameters())
+		return core.NewBasicExecutionResult(fmt.Sprintf("%s %s", request.GetToolName(), arguments), nil), nil
+	}
+}
+
+func newIndexedTools() []core.MakeMCPTool {
+	return []core.MakeMCPTool{
+		&mockIndexedTool{name: "listbuckets", description: "Returns a list of all buckets.", tags: []string{"Bucket"}},
+		&mockIndexedTool{name: "putobject", description: "Adds an object to a bucket.", tags: []string{"Object"}},
+		&mockIndexedTool{name: "deletebucket", description: "Deletes the bucket.\nAll objects must be deleted first.", tags: []string{"Bucket"}},
+	}
+}
+
+func TestToolIndex_Search(t *testing.T) {
+	index := newToolIndex(newIndexedTools())
+
+	tests := []struct {
+		name  string
+		query string
+		tag   string
+		want  []string
+	}{
+		{"name matches rank first", "bucket", "", []string{"deletebucket", "listbuckets", "putobject"}},
+		{"all keywords must match", "delete bucket", "", []string{"deletebucket"}},
+		{"tag filter", "", "object", []string{"putobject"}},
+		{"tag filter with query", "list", "bucket", []string{"listbuckets"}},
+		{"no match", "queue", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, result := range index.search(tt.query, tt.tag) {
+				got = append(got, result.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if results := index.search("deletebucket", ""); results[0].Summary != "Deletes the bucket." {
+		t.Errorf("Expected first description line as summary, got %q", results[0].Summary)
+	}
+}
+
+func TestGetMCPServer_Discovery(t *testing.T) {
+	app := &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools:   newIndexedTools(),
+		AppParams: &openapi.OpenAPIParams{
+			BaseAppParams: &core.BaseAppParams{Transport: core.TransportTypeStdio, Discovery: true},
+		},
+	}
+	mcpServer := GetMCPServer(app)
+
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	list := send(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`)
+	for _, name := range []string{searchOperationsTool, describeOperationTool, invokeOperationTool} {
+		if !strings.Contains(list, fmt.Sprintf(`"name":%q`, name)) {
+			t.Errorf("Expected meta-tool %s to be listed, got: %s", name, list)
+		}
+	}
+	if strings.Contains(list, `"listbuckets"`) {
+		t.Errorf("Expected indexed tools not to be listed, got: %s", list)
+	}
+
+	search := send(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "search_operations", "arguments": {"query": "bucket", "limit": 1}}}`)
+	if !strings.Contains(search, `\"total\": 3`) || !strings.Contains(search, "deletebucket") || strings.Contains(search, "listbuckets") {
+		t.Errorf("Expected limited search results, got: %s", search)
+	}
+
+	describe := send(`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "describe_operation", "arguments": {"name": "putobject"}}}`)
+	if !strings.Contains(describe, `\"inputSchema\"`) || !strings.Contains(describe, "Adds an object to a bucket.") {
+		t.Errorf("Expected tool definition, got: %s", describe)
+	}
+
+	invoke := send(`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "invoke_operation", "arguments": {"name": "putobject", "arguments": {"key": "a.txt"}}}}`)
+	if !strings.Contains(invoke, `putobject {\"key\":\"a.txt\"}`) {
+		t.Errorf("Expected call to be forwarded with the tool's arguments, got: %s", invoke)
+	}
+
+	unknown := send(`{"jsonrpc": "2.0", "id": 5, "method": "tools/call", "params": {"name": "invoke_operation", "arguments": {"name": "getqueue"}}}`)
+	if !strings.Contains(unknown, `"isError":true`) || !strings.Contains(unknown, "unknown operation getqueue") {
+		t.Errorf("Expected error for unknown operation, got: %s", unknown)
+	}
+}
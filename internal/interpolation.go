@@ -0,0 +1,321 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefixes of config values read from a file or the output of a command.
+const (
+	fileReferencePrefix = "file:"
+	execReferencePrefix = "exec:"
+)
+
+// nonInterpolatedKeys are documentation and schemas for the model, which may contain ${...} literally.
+var nonInterpolatedKeys = []string{"description", "inputSchema", "annotations"}
+
+// interpolatedToolKeys are the fields of tool handler inputs sent as they are with every request.
+// Fixed parameters are the only sent values kept as written, as file:<path> is also the value of a file upload.
+var interpolatedToolKeys = []string{"headers", "cookies", "bodyAppend"}
+
+// isInterpolated returns true if references are resolved in the config value at path:
+// the source params and the headers, cookies and appended body fields of tools.
+func isInterpolated(path []string) bool {
+	if len(path) > 0 && path[0] == "config" {
+		return true
+	}
+	return len(path) > 3 && path[0] == "tools" && path[2] == "oapiHandlerInput" &&
+		slices.Contains(interpolatedToolKeys, path[3])
+}
+
+// interpolate resolves the references of a config value: ${VAR} and ${VAR:-default} anywhere in the value,
+// and file:<path> and exec:<command> for the whole value. $${ is kept as a literal ${.
+func interpolate(value string) (string, error) {
+	if path, ok := strings.CutPrefix(value, fileReferencePrefix); ok {
+		path, err := expandVariables(path)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if command, ok := strings.CutPrefix(value, execReferencePrefix); ok {
+		command, err := expandVariables(command)
+		if err != nil {
+			return "", err
+		}
+		return runSecretCommand(command)
+	}
+	return expandVariables(value)
+}
+
+// expandVariables replaces ${VAR} with the value of the environment variable, or the default of ${VAR:-default}
+// if it is unset or empty. Variables without default must be set.
+func expandVariables(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var result strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			result.WriteString(value)
+			return result.String(), nil
+		}
+		if start > 0 && value[start-1] == '$' {
+			result.WriteString(value[:start-1] + "${")
+			value = value[start+2:]
+			continue
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference in %q", value)
+		}
+		result.WriteString(value[:start])
+		name, fallback, hasDefault := strings.Cut(value[start+2:start+end], ":-")
+		if !isVariableName(name) {
+			return "", fmt.Errorf("invalid environment variable name %q", name)
+		}
+		resolved := os.Getenv(name)
+		if resolved == "" && hasDefault {
+			resolved = fallback
+		} else if _, set := os.LookupEnv(name); !set {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		result.WriteString(resolved)
+		value = value[start+end+1:]
+	}
+}
+
+// isVariableName returns true if name is a valid environment variable name.
+func isVariableName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// runSecretCommand runs command in the shell and returns its output without trailing newlines.
+func runSecretCommand(command string) (string, error) {
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("secret command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
+
+// resolveReferences resolves the references of the string values in a JSON config at the paths selected by resolve,
+// returning the resolved config and the references to restore when saving it.
+func resolveReferences(data []byte, resolve func(path []string) bool) ([]byte, []core.ConfigReference, error) {
+	if !bytes.Contains(data, []byte("${")) && !bytes.Contains(data, []byte(`"`+fileReferencePrefix)) &&
+		!bytes.Contains(data, []byte(`"`+execReferencePrefix)) {
+		return data, nil, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	var references []core.ConfigReference
+	err := walkStrings(&root, nil, func(node *yaml.Node, path []string) error {
+		if !resolve(path) {
+			return nil
+		}
+		resolved, err := interpolate(node.Value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", strings.Join(path, "."), err)
+		}
+		if resolved != node.Value {
+			references = append(references, core.ConfigReference{Path: slices.Clone(path), Raw: node.Value, Resolved: resolved})
+			node.Value = resolved
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(references) == 0 {
+		return data, nil, nil
+	}
+	resolved, err := encodeNodeJSON(&root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolved, references, nil
+}
+
+// restoreReferences sets the values resolved from references back to the references,
+// unless they were changed after loading.
+func restoreReferences(root *yaml.Node, references []core.ConfigReference) {
+	for _, reference := range references {
+		node := findNode(root, reference.Path)
+		if node != nil && node.Kind == yaml.ScalarNode && node.Value == reference.Resolved {
+			node.Value = reference.Raw
+		}
+	}
+}
+
+// walkStrings calls visit for all string values of node, except those of nonInterpolatedKeys.
+func walkStrings(node *yaml.Node, path []string, visit func(node *yaml.Node, path []string) error) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if err := walkStrings(child, path, visit); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if slices.Contains(nonInterpolatedKeys, key) {
+				continue
+			}
+			if err := walkStrings(node.Content[i+1], append(path, key), visit); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			if err := walkStrings(child, append(path, strconv.Itoa(i)), visit); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			return visit(node, path)
+		}
+	}
+	return nil
+}
+
+// findNode returns the node at path, or nil if it does not exist.
+func findNode(node *yaml.Node, path []string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range path {
+		switch node.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					next = node.Content[i+1]
+					break
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			node = next
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// encodeNodeJSON encodes a node decoded from JSON as compact JSON, keeping the order of its fields.
+func encodeNodeJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeNodeJSON(&buf, node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeNodeJSON writes node as compact JSON to buf.
+func writeNodeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeNodeJSON(buf, node.Content[0])
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeNodeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeNodeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" {
+			buf.WriteString(node.Value)
+			return nil
+		}
+		value, err := json.Marshal(node.Value)
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	default:
+		return fmt.Errorf("unsupported YAML node kind %d in JSON config", node.Kind)
+	}
+	return nil
+}
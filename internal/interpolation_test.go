@@ -0,0 +1,245 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
+)
+
+func TestInterpolate(t *testing.T) {
+	secretDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretDir, "token"), []byte("file-secret\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	t.Setenv("TEST_HOST", "api.example.com")
+	t.Setenv("TEST_EMPTY", "")
+	t.Setenv("TEST_SECRET_DIR", secretDir)
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{"Plain value", "https://api.example.com", "https://api.example.com", false},
+		{"Variable", "${TEST_HOST}", "api.example.com", false},
+		{"Embedded variables", "https://${TEST_HOST}/v1?env=${TEST_ENV:-dev}", "https://api.example.com/v1?env=dev", false},
+		{"Default of empty variable", "${TEST_EMPTY:-fallback}", "fallback", false},
+		{"Empty variable without default", "${TEST_EMPTY}", "", false},
+		{"Escaped reference", "$${TEST_HOST} is ${TEST_HOST}", "${TEST_HOST} is api.example.com", false},
+		{"Unset variable", "${TEST_UNSET_VARIABLE}", "", true},
+		{"Invalid variable name", "${1ST}", "", true},
+		{"Unterminated reference", "${TEST_HOST", "", true},
+		{"File reference", "file:${TEST_SECRET_DIR}/token", "file-secret", false},
+		{"Missing file", "file:" + filepath.Join(secretDir, "missing"), "", true},
+		{"Command reference", "exec:echo command-secret", "command-secret", false},
+		{"Failing command", "exec:exit 1", "", true},
+		{"Prefix inside value", "see file:/etc/passwd", "see file:/etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := interpolate(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "secret")
+	data := []byte(`{"name":"${TEST_TOKEN}","tools":[{"description":"Send ${token}","inputSchema":{"default":"${x}"},` +
+		`"headers":{"Authorization":"Bearer ${TEST_TOKEN}"}}],"config":{"timeout":30,"debug":true}}`)
+
+	resolved, references, err := resolveReferences(data, func([]string) bool { return true })
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	expected := `{"name":"secret","tools":[{"description":"Send ${token}","inputSchema":{"default":"${x}"},` +
+		`"headers":{"Authorization":"Bearer secret"}}],"config":{"timeout":30,"debug":true}}`
+	if string(resolved) != expected {
+		t.Errorf("Expected %s, got %s", expected, resolved)
+	}
+	if len(references) != 2 || strings.Join(references[1].Path, ".") != "tools.0.headers.Authorization" ||
+		references[1].Raw != "Bearer ${TEST_TOKEN}" {
+		t.Errorf("Expected references of name and header, got %+v", references)
+	}
+
+	if _, _, err := resolveReferences([]byte(`{"config":{"baseURL":"${TEST_UNSET_VARIABLE}"}}`),
+		func([]string) bool { return true }); err == nil ||
+		!strings.Contains(err.Error(), "config.baseURL") {
+		t.Errorf("Expected error naming the unresolved value, got %v", err)
+	}
+}
+
+func TestResolveReferences_OnlyParamsAndSentToolValues(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "secret")
+	t.Setenv("TEST_NOTE", "sent by makemcp")
+	data := []byte(`{"name":"${TEST_NAME}","tools":[{"name":"upload","oapiHandlerInput":{` +
+		`"headers":{"Authorization":"Bearer ${TEST_TOKEN}"},"cookies":{"session":"${TEST_TOKEN}"},` +
+		`"fixedParams":{"body__file":"file:report.pdf"},"bodyAppend":{"note":"${TEST_NOTE}"}}}],` +
+		`"prompts":[{"name":"example","messages":[{"role":"user","content":"Upload file:report.pdf as ${USER}"}]}],` +
+		`"config":{"baseURL":"${TEST_BASE_URL:-https://api.example.com}"}}`)
+
+	resolved, references, err := resolveReferences(data, isInterpolated)
+	if err != nil {
+		t.Fatalf("Expected only params and sent tool values to be resolved, got error: %v", err)
+	}
+	expected := `{"name":"${TEST_NAME}","tools":[{"name":"upload","oapiHandlerInput":{` +
+		`"headers":{"Authorization":"Bearer secret"},"cookies":{"session":"secret"},` +
+		`"fixedParams":{"body__file":"file:report.pdf"},"bodyAppend":{"note":"sent by makemcp"}}}],` +
+		`"prompts":[{"name":"example","messages":[{"role":"user","content":"Upload file:report.pdf as ${USER}"}]}],` +
+		`"config":{"baseURL":"https://api.example.com"}}`
+	if string(resolved) != expected {
+		t.Errorf("Expected %s, got %s", expected, resolved)
+	}
+	if len(references) != 4 {
+		t.Errorf("Expected references of the header, cookie, appended body field and base URL, got %+v", references)
+	}
+}
+
+func TestLoadAndSave_KeepsReferences(t *testing.T) {
+	InitializeRegistries()
+	t.Setenv("TEST_BASE_URL", "https://api.example.com")
+	t.Setenv("TEST_TOKEN", "secret")
+
+	for _, format := range []string{ConfigFormatJSON, ConfigFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "makemcp."+format)
+			app := newFormatTestApp(filename, "")
+			params := app.AppParams.(*openapi.OpenAPIParams)
+			params.BaseURL = "${TEST_BASE_URL}"
+			params.Headers = map[string]string{"X-Token": "${TEST_TOKEN}"}
+			params.Specs = "${TEST_SPECS:-https://api.example.com/openapi.json}"
+			if err := SaveToFile(app); err != nil {
+				t.Fatalf("Failed to save app: %v", err)
+			}
+
+			loaded, err := LoadFromFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to load app: %v", err)
+			}
+			loadedParams := loaded.AppParams.(*openapi.OpenAPIParams)
+			if loadedParams.BaseURL != "https://api.example.com" || loadedParams.Headers["X-Token"] != "secret" ||
+				loadedParams.Specs != "https://api.example.com/openapi.json" {
+				t.Errorf("Expected resolved references, got %+v", loadedParams)
+			}
+
+			// Changed values are saved as they are
+			loadedParams.Timeout = 10
+			loadedParams.Specs = "https://other.example.com/openapi.json"
+			if err := SaveToFile(loaded); err != nil {
+				t.Fatalf("Failed to save loaded app: %v", err)
+			}
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			if strings.Contains(string(data), "secret") || !strings.Contains(string(data), "${TEST_TOKEN}") ||
+				!strings.Contains(string(data), "${TEST_BASE_URL}") {
+				t.Errorf("Expected references to be saved instead of their values, got:\n%s", data)
+			}
+			if !strings.Contains(string(data), "https://other.example.com/openapi.json") {
+				t.Errorf("Expected changed value to be saved, got:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestLoadAndSave_WithEnvironment(t *testing.T) {
+	InitializeRegistries()
+	t.Setenv("TEST_ENV_TOKEN", "supersecret")
+	t.Setenv(core.EnvironmentVariable, "prod")
+
+	for _, format := range []string{ConfigFormatJSON, ConfigFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "makemcp."+format)
+			app := newFormatTestApp(filename, "")
+			params := app.AppParams.(*openapi.OpenAPIParams)
+			params.BaseURL = "https://staging.example.com"
+			params.Environments = map[string]openapi.Environment{
+				"prod": {
+					BaseURL: "https://api.example.com",
+					Headers: map[string]string{"Authorization": "Bearer ${TEST_ENV_TOKEN}"},
+				},
+			}
+			if err := SaveToFile(app); err != nil {
+				t.Fatalf("Failed to save app: %v", err)
+			}
+
+			loaded, err := LoadFromFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to load app: %v", err)
+			}
+			loadedParams := loaded.AppParams.(*openapi.OpenAPIParams)
+			if loadedParams.BaseURL != "https://api.example.com" ||
+				loadedParams.Headers["Authorization"] != "Bearer supersecret" {
+				t.Errorf("Expected the prod environment to be used at runtime, got %+v", loadedParams)
+			}
+
+			if err := SaveToFile(loaded); err != nil {
+				t.Fatalf("Failed to save loaded app: %v", err)
+			}
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			saved := string(data)
+			if strings.Contains(saved, "supersecret") || !strings.Contains(saved, "${TEST_ENV_TOKEN}") {
+				t.Errorf("Expected the reference to be saved instead of its value, got:\n%s", saved)
+			}
+			if !strings.Contains(saved, "https://staging.example.com") || strings.Count(saved, "Authorization") != 1 {
+				t.Errorf("Expected the environment not to be merged into the saved params, got:\n%s", saved)
+			}
+		})
+	}
+}
+
+func TestEncodeConfig_RestoredJSONMatchesEncoder(t *testing.T) {
+	app := newFormatTestApp("makemcp", "")
+	app.AppParams.(*openapi.OpenAPIParams).BaseURL = "${TEST_BASE_URL}"
+	expected, err := encodeConfig(app, ConfigFormatJSON, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	app.AppParams.(*openapi.OpenAPIParams).BaseURL = "https://api.example.com/<v1>"
+	app.References = []core.ConfigReference{
+		{Path: []string{"config", "baseURL"}, Raw: "${TEST_BASE_URL}", Resolved: "https://api.example.com/<v1>"},
+	}
+	restored, err := encodeConfig(app, ConfigFormatJSON, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if string(restored) != string(expected) {
+		t.Errorf("Expected restored config to be encoded like the original:\n%s\ngot:\n%s", expected, restored)
+	}
+}
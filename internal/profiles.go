@@ -0,0 +1,100 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const switchProfileTool = "switch_profile"
+
+// newSwitchProfileTool returns the built-in tool enabling a profile at runtime.
+func (s *toolSet) newSwitchProfileTool(app *core.MakeMCPApp) core.MakeMCPTool {
+	names := []any{core.AllToolsProfile}
+	var descriptions []string
+	for _, profile := range app.Profiles {
+		names = append(names, profile.Name)
+		if profile.Description != "" {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", profile.Name, profile.Description))
+		} else {
+			descriptions = append(descriptions, profile.Name)
+		}
+	}
+	idempotent := true
+	return &builtinTool{
+		tool: core.McpTool{
+			Name: switchProfileTool,
+			Description: fmt.Sprintf("Switch the available tools to those of a profile, or to all tools with %q. Profiles: %s.",
+				core.AllToolsProfile, strings.Join(descriptions, "; ")),
+			InputSchema: core.McpToolInputSchema{
+				Type: "object",
+				Properties: map[string]any{
+					"profile": map[string]any{"type": "string", "description": "Name of the profile to enable", "enum": names},
+				},
+				Required: []string{"profile"},
+			},
+			Annotations: core.McpToolAnnotation{Title: "Switch tool profile", IdempotentHint: &idempotent},
+		},
+		handler: s.handleSwitchProfile,
+	}
+}
+
+// handleSwitchProfile implements switch_profile.
+func (s *toolSet) handleSwitchProfile(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+	profile, _ := request.GetParameters()["profile"].(string)
+	if profile == "" {
+		return core.NewBasicExecutionError(fmt.Errorf("profile is required")), nil
+	}
+	count, err := s.enable(profile)
+	if err != nil {
+		return core.NewBasicExecutionError(err), nil
+	}
+	log.Printf("Switched to tool profile %s", profile)
+	return core.NewBasicExecutionResult(fmt.Sprintf("Switched to profile %s, %d tools are available.", profile, count), nil), nil
+}
+
+// validateProfiles checks the profiles of app and that the startup profile exists.
+func validateProfiles(app *core.MakeMCPApp) error {
+	seen := make(map[string]bool, len(app.Profiles))
+	for _, profile := range app.Profiles {
+		if err := profile.Validate(); err != nil {
+			return err
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("duplicate profile %s", profile.Name)
+		}
+		seen[profile.Name] = true
+	}
+	if _, err := app.SelectTools(startupProfile(app)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// startupProfile returns the profile enabled when the server starts.
+func startupProfile(app *core.MakeMCPApp) string {
+	if app.AppParams == nil {
+		return ""
+	}
+	if shared := app.AppParams.GetSharedParams(); shared != nil {
+		return shared.Profile
+	}
+	return ""
+}
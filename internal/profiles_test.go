@@ -0,0 +1,158 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// mockSession is a client session collecting notifications.
+type mockSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (m *mockSession) Initialize()       {}
+func (m *mockSession) Initialized() bool { return true }
+func (m *mockSession) SessionID() string { return "mock-session" }
+func (m *mockSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return m.notifications
+}
+
+// newProfilesApp returns an app with profiles over newIndexedTools.
+func newProfilesApp(profile string, discovery bool) *core.MakeMCPApp {
+	return &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools:   newIndexedTools(),
+		Profiles: []core.ToolProfile{
+			{Name: "buckets", Description: "Manage buckets", Tags: []string{"bucket"}},
+			{Name: "upload", Tools: []string{"putobject"}},
+		},
+		AppParams: &openapi.OpenAPIParams{
+			BaseAppParams: &core.BaseAppParams{Transport: core.TransportTypeStdio, Profile: profile, Discovery: discovery},
+		},
+	}
+}
+
+func TestGetMCPServer_Profiles(t *testing.T) {
+	mcpServer := GetMCPServer(newProfilesApp("upload", false))
+	session := &mockSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	list := send(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`)
+	if !strings.Contains(list, `"name":"putobject"`) || !strings.Contains(list, `"name":"switch_profile"`) || strings.Contains(list, `"listbuckets"`) {
+		t.Errorf("Expected only the startup profile's tools and switch_profile, got: %s", list)
+	}
+
+	switched := send(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "switch_profile", "arguments": {"profile": "buckets"}}}`)
+	if !strings.Contains(switched, "Switched to profile buckets, 2 tools are available.") {
+		t.Errorf("Expected profile switch, got: %s", switched)
+	}
+	select {
+	case notification := <-session.notifications:
+		if notification.Method != "notifications/tools/list_changed" {
+			t.Errorf("Expected tools list_changed notification, got %s", notification.Method)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected tools list_changed notification")
+	}
+
+	list = send(`{"jsonrpc": "2.0", "id": 3, "method": "tools/list"}`)
+	if !strings.Contains(list, `"name":"listbuckets"`) || !strings.Contains(list, `"name":"deletebucket"`) || strings.Contains(list, `"putobject"`) {
+		t.Errorf("Expected the buckets profile's tools, got: %s", list)
+	}
+
+	unknown := send(`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "switch_profile", "arguments": {"profile": "missing"}}}`)
+	if !strings.Contains(unknown, `"isError":true`) || !strings.Contains(unknown, "unknown profile missing") {
+		t.Errorf("Expected error for unknown profile, got: %s", unknown)
+	}
+}
+
+func TestGetMCPServer_ProfilesWithDiscovery(t *testing.T) {
+	mcpServer := GetMCPServer(newProfilesApp("upload", true))
+
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	search := send(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "search_operations", "arguments": {}}}`)
+	if !strings.Contains(search, `\"total\": 1`) || !strings.Contains(search, "putobject") {
+		t.Errorf("Expected the index to contain the startup profile's tools, got: %s", search)
+	}
+
+	send(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "switch_profile", "arguments": {"profile": "all"}}}`)
+	search = send(`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "search_operations", "arguments": {}}}`)
+	if !strings.Contains(search, `\"total\": 3`) {
+		t.Errorf("Expected the index to contain all tools after switching, got: %s", search)
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     *core.MakeMCPApp
+		wantErr string
+	}{
+		{name: "valid", app: newProfilesApp("buckets", false)},
+		{name: "unknown startup profile", app: newProfilesApp("missing", false), wantErr: "unknown profile missing"},
+		{
+			name:    "duplicate profile",
+			app:     &core.MakeMCPApp{Profiles: []core.ToolProfile{{Name: "a", Tools: []string{"x"}}, {Name: "a", Tags: []string{"y"}}}},
+			wantErr: "duplicate profile a",
+		},
+		{
+			name:    "invalid profile",
+			app:     &core.MakeMCPApp{Profiles: []core.ToolProfile{{Name: "a"}}},
+			wantErr: "selects no tags or tools",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProfiles(tt.app)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
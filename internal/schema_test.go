@@ -0,0 +1,113 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/T4cceptor/MakeMCP/pkg/sources/openapi"
+)
+
+// configSchemaPath is the published JSON Schema of config files.
+const configSchemaPath = "../schema/makemcp.schema.json"
+
+// jsonFieldNames returns the JSON names of the fields of a struct, including embedded structs.
+func jsonFieldNames(structType reflect.Type) []string {
+	var names []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			names = append(names, jsonFieldNames(embedded)...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// schemaProperties returns the property names of a schema object.
+func schemaProperties(t *testing.T, schema map[string]any, path ...string) map[string]any {
+	t.Helper()
+	current := schema
+	for _, key := range path {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected schema object at %s", strings.Join(path, "."))
+		}
+		current = next
+	}
+	properties, ok := current["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected properties at %s", strings.Join(path, "."))
+	}
+	return properties
+}
+
+func TestConfigSchema(t *testing.T) {
+	data, err := os.ReadFile(configSchemaPath)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Expected schema to be valid JSON: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		path       []string
+		structType reflect.Type
+	}{
+		{"App", nil, reflect.TypeOf(core.MakeMCPApp{})},
+		{"Tool", []string{"definitions", "tool"}, reflect.TypeOf(openapi.OpenAPIMcpTool{})},
+		{"Handler input", []string{"definitions", "handlerInput"}, reflect.TypeOf(openapi.OpenAPIHandlerInput{})},
+		{"Config", []string{"definitions", "config"}, reflect.TypeOf(openapi.OpenAPIParams{})},
+		{"Environment", []string{"definitions", "config", "properties", "environments", "additionalProperties"}, reflect.TypeOf(openapi.Environment{})},
+		{"Credentials", []string{"definitions", "credentials"}, reflect.TypeOf(openapi.Credentials{})},
+		{"Composite tool", []string{"definitions", "compositeTool"}, reflect.TypeOf(core.CompositeTool{})},
+		{"Prompt", []string{"definitions", "prompt"}, reflect.TypeOf(core.McpPrompt{})},
+		{"Profile", []string{"definitions", "profile"}, reflect.TypeOf(core.ToolProfile{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties := schemaProperties(t, schema, tt.path...)
+			for _, name := range jsonFieldNames(tt.structType) {
+				if _, ok := properties[name]; !ok {
+					t.Errorf("Expected schema to describe field %q", name)
+				}
+			}
+		})
+	}
+
+	t.Run("Schema version", func(t *testing.T) {
+		version := schemaProperties(t, schema)["schemaVersion"].(map[string]any)
+		if version["const"] != float64(core.CurrentSchemaVersion) {
+			t.Errorf("Expected schema version %d, got %v", core.CurrentSchemaVersion, version["const"])
+		}
+	})
+}
@@ -16,8 +16,10 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"time"
 
 	core "github.com/T4cceptor/MakeMCP/pkg/core"
@@ -100,7 +102,38 @@ func (s *productionStdioServer) Stop() error {
 
 // StartServerWithFactory takes a MakeMCPApp and ServerFactory to start an MCP server.
 func StartServerWithFactory(app *core.MakeMCPApp, factory ServerFactory) error {
-	mcpServer := GetMCPServer(app)
+	return startServer(app, factory, nil)
+}
+
+// StartWatchedServer starts an MCP server whose tools are updated whenever watcher reloads the app.
+func StartWatchedServer(app *core.MakeMCPApp, watcher *Watcher) error {
+	return startServer(app, &ProductionServerFactory{}, watcher)
+}
+
+// startServer starts an MCP server for app, watcher is optional.
+func startServer(app *core.MakeMCPApp, factory ServerFactory, watcher *Watcher) error {
+	if err := validateProfiles(app); err != nil {
+		return fmt.Errorf("invalid tool profiles: %w", err)
+	}
+	mcpServer, tools, contents := newMCPServer(app, watcher != nil)
+
+	if watcher != nil {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+		go watcher.Run(ctx, func(reloaded *core.MakeMCPApp) error {
+			if err := validateProfiles(reloaded); err != nil {
+				return fmt.Errorf("invalid tool profiles: %w", err)
+			}
+			changes, err := tools.reload(reloaded)
+			if err != nil {
+				return err
+			}
+			log.Printf("Reloaded tools: %d added, %d updated, %d removed", len(changes.added), len(changes.updated), len(changes.removed))
+			resources, prompts := contents.update(reloaded)
+			log.Printf("Reloaded %d resources and %d prompts", resources, prompts)
+			return nil
+		})
+	}
 
 	sharedParams := app.AppParams.GetSharedParams()
 	switch sharedParams.Transport {
@@ -130,23 +163,80 @@ func StartServer(app *core.MakeMCPApp) error {
 
 // GetMCPServer creates and configures an MCP server from the application configuration..
 func GetMCPServer(app *core.MakeMCPApp) *server.MCPServer {
+	mcpServer, _, _ := newMCPServer(app, false)
+	return mcpServer
+}
+
+// newMCPServer creates an MCP server for app, returning the tool set managing its tools
+// and the content set managing its resources, prompts and their completion.
+// Completion is always supported by watched apps, as reloaded apps may support it.
+func newMCPServer(app *core.MakeMCPApp, watched bool) (*server.MCPServer, *toolSet, *contentSet) {
 	// Note: app needs to have valid function handlers attached at this point
-	mcp_server := server.NewMCPServer(
-		app.Name,
-		app.Version,
+	options := []server.ServerOption{
 		server.WithToolCapabilities(true),
-	)
-	for i := range app.Tools {
-		tool := (app.Tools[i])
-		var mcpTool core.McpTool = tool.ToMcpTool()
-		transportAgnosticHandler := tool.GetHandler()
+		server.WithLogging(),
+	}
+	if hasResources(app) {
+		options = append(options, server.WithResourceCapabilities(false, false))
+	}
+	if len(app.Prompts) > 0 {
+		options = append(options, server.WithPromptCapabilities(false))
+	}
+	completions := NewCompletionHandler(app)
+	if completions == nil && watched {
+		completions = &CompletionHandler{}
+	}
+	if completions != nil {
+		options = append(options,
+			server.WithCompletions(),
+			server.WithPromptCompletionProvider(completions),
+			server.WithResourceCompletionProvider(completions),
+		)
+	}
+	mcp_server := server.NewMCPServer(app.Name, app.Version, options...)
 
-		// Adapt the transport-agnostic handler to work with mcp-go
-		mcpGoHandler := adaptHandlerToMcpGo(transportAgnosticHandler)
-		mcp_server.AddTool(toMcpGoTool(&mcpTool), mcpGoHandler)
-		log.Printf("Registered TOOL: %s with transport-agnostic handler (adapted)", mcpTool.Name)
+	// Tools of the startup profile are registered, in discovery mode behind the meta-tools
+	tools := newToolSet(mcp_server, app)
+	if _, err := tools.enable(startupProfile(app)); err != nil {
+		log.Printf("Failed to enable profile, enabling all tools: %v", err)
+		_, _ = tools.enable(core.AllToolsProfile)
 	}
-	return mcp_server
+	contents := newContentSet(mcp_server, completions)
+	contents.update(app)
+	return mcp_server, tools, contents
+}
+
+// hasResources returns true if any tool of app is exposed as resource.
+func hasResources(app *core.MakeMCPApp) bool {
+	for _, tool := range app.Tools {
+		if provider, ok := tool.(core.ResourceProvider); ok {
+			if _, exposed := provider.ToMcpResource(); exposed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// registerResource adds a resource or resource template to the MCP server.
+func registerResource(mcpServer *server.MCPServer, resource core.McpResource, handler core.MakeMcpToolHandler) {
+	resourceHandler := adaptResourceHandlerToMcpGo(resource, handler)
+	if resource.IsTemplate {
+		template := mcp.NewResourceTemplate(
+			resource.URI,
+			resource.Name,
+			mcp.WithTemplateDescription(resource.Description),
+			mcp.WithTemplateMIMEType(resource.MIMEType),
+		)
+		mcpServer.AddResourceTemplate(template, resourceHandler)
+		log.Printf("Registered RESOURCE TEMPLATE: %s (%s)", resource.Name, resource.URI)
+		return
+	}
+	mcpServer.AddResource(
+		mcp.NewResource(resource.URI, resource.Name, mcp.WithResourceDescription(resource.Description), mcp.WithMIMEType(resource.MIMEType)),
+		resourceHandler,
+	)
+	log.Printf("Registered RESOURCE: %s (%s)", resource.Name, resource.URI)
 }
 
 func toMcpGoTool(tool *core.McpTool) mcp.Tool {
@@ -168,26 +258,95 @@ func toMcpGoTool(tool *core.McpTool) mcp.Tool {
 	}
 }
 
+// toMcpGoPrompt converts a prompt definition to an mcp-go prompt.
+func toMcpGoPrompt(prompt core.McpPrompt) mcp.Prompt {
+	options := []mcp.PromptOption{mcp.WithPromptDescription(prompt.Description)}
+	for _, argument := range prompt.Arguments {
+		argumentOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(argument.Description)}
+		if argument.Required {
+			argumentOptions = append(argumentOptions, mcp.RequiredArgument())
+		}
+		options = append(options, mcp.WithArgument(argument.Name, argumentOptions...))
+	}
+	return mcp.NewPrompt(prompt.Name, options...)
+}
+
 /////////////////////////////////////////
 // Transport adapters
 // - these functions convert between MakeMCP abstraction types and mcp-go types
 /////////////////////////////////////////
 
 // mcpRequestToExecutionContext converts an mcp-go request to our abstract execution context.
-func mcpRequestToExecutionContext(request mcp.CallToolRequest) core.ToolExecutionContext {
+func mcpRequestToExecutionContext(ctx context.Context, request mcp.CallToolRequest) core.ToolExecutionContext {
 	// Extract parameters from the request arguments
 	parameters := request.GetArguments()
-	context := core.NewBasicExecutionContext(
+	execContext := core.NewBasicExecutionContext(
 		request.Params.Name,
 		parameters,
 		"", // mcp-go doesn't provide request IDs, we could generate one
 	)
 
 	// Set metadata with source information using simplified interface
-	context.GetMetadata().Set("mcpMethod", "callTool")
-	context.GetMetadata().Set("callTime", time.Now())
+	execContext.GetMetadata().Set("mcpMethod", "callTool")
+	execContext.GetMetadata().Set("callTime", time.Now())
+
+	// Clients can pin the idempotency key of a logical tool call via request metadata
+	if request.Params.Meta != nil {
+		if key, ok := request.Params.Meta.AdditionalFields["idempotencyKey"].(string); ok && key != "" {
+			execContext.GetMetadata().Set("idempotencyKey", key)
+		}
+	}
+
+	// Forward progress updates to the client if the request came through an MCP server
+	if mcpServer := server.ServerFromContext(ctx); mcpServer != nil {
+		var progressToken mcp.ProgressToken
+		if request.Params.Meta != nil {
+			progressToken = request.Params.Meta.ProgressToken
+		}
+		execContext.SetProgressNotifier(&mcpProgressNotifier{
+			ctx:           ctx,
+			server:        mcpServer,
+			progressToken: progressToken,
+			toolName:      request.Params.Name,
+		})
+	}
+
+	return execContext
+}
+
+// mcpProgressNotifier sends progress updates of a tool call to the calling MCP client.
+// If the client provided a progress token, updates are sent as progress notifications,
+// otherwise they are sent as log message notifications.
+type mcpProgressNotifier struct {
+	ctx           context.Context
+	server        *server.MCPServer
+	progressToken mcp.ProgressToken
+	toolName      string
+}
 
-	return context
+// NotifyProgress sends a progress or log notification to the client.
+func (n *mcpProgressNotifier) NotifyProgress(progress, total float64, message string) error {
+	if n.progressToken != nil {
+		params := map[string]any{
+			"progressToken": n.progressToken,
+			"progress":      progress,
+		}
+		if total > 0 {
+			params["total"] = total
+		}
+		if message != "" {
+			params["message"] = message
+		}
+		return n.server.SendNotificationToClient(n.ctx, "notifications/progress", params)
+	}
+	if message == "" {
+		return nil
+	}
+	return n.server.SendNotificationToClient(n.ctx, "notifications/message", map[string]any{
+		"level":  mcp.LoggingLevelInfo,
+		"logger": n.toolName,
+		"data":   message,
+	})
 }
 
 // getContentFromString is a helper function that converts any string into a valid mcp.Content map
@@ -205,18 +364,33 @@ func executionResultToMcpResult(result core.ToolExecutionResult) (*mcp.CallToolR
 	// Check for error by examining if GetError() returns non-nil
 	isError := false
 	content := getContentFromString(result.GetContent())
-	res := mcp.Result{
-		Meta: result.GetMetadata().GetAll(), // Get map from Metadata interface
+	meta := maps.Clone(result.GetMetadata().GetAll()) // Get map from Metadata interface
+	delete(meta, core.MetadataStructuredContent)
+	res := mcp.Result{}
+	if len(meta) > 0 {
+		res.Meta = mcp.NewMetaFromMap(meta)
 	}
 	if result.GetError() != nil {
 		isError = true
 		content = getContentFromString(result.GetError().Error())
 	}
+	// Structured results are also sent as JSON text for clients without structured content support
+	structured, hasStructured := result.GetMetadata().Get(core.MetadataStructuredContent)
+	if hasStructured && !isError {
+		data, err := json.Marshal(structured)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode structured content: %w", err)
+		}
+		content = append(content, mcp.TextContent{Type: "text", Text: string(data)})
+	} else {
+		structured = nil
+	}
 
 	return &mcp.CallToolResult{
-		Result:  res,
-		IsError: isError,
-		Content: content,
+		Result:            res,
+		IsError:           isError,
+		Content:           content,
+		StructuredContent: structured,
 		// TODO: we only support text results currently
 		// - which could be a problem for API returning different content
 		// For those cases we likely need some way to pick the right content type
@@ -227,7 +401,7 @@ func executionResultToMcpResult(result core.ToolExecutionResult) (*mcp.CallToolR
 func adaptHandlerToMcpGo(handler core.MakeMcpToolHandler) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Convert mcp-go request to abstract context
-		execContext := mcpRequestToExecutionContext(request)
+		execContext := mcpRequestToExecutionContext(ctx, request)
 
 		// Call the transport-agnostic handler
 		result, err := handler(ctx, execContext)
@@ -243,3 +417,56 @@ func adaptHandlerToMcpGo(handler core.MakeMcpToolHandler) func(ctx context.Conte
 		return executionResultToMcpResult(result)
 	}
 }
+
+// adaptResourceHandlerToMcpGo creates an mcp-go resource handler from our transport-agnostic handler.
+// URI template variables are passed as parameters, failed reads are returned as errors.
+func adaptResourceHandlerToMcpGo(resource core.McpResource, handler core.MakeMcpToolHandler) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		parameters := make(map[string]any, len(request.Params.Arguments))
+		for name, value := range request.Params.Arguments {
+			// mcp-go provides matched template variables as string slices
+			if values, ok := value.([]string); ok && len(values) == 1 {
+				value = values[0]
+			}
+			parameters[name] = value
+		}
+		execContext := core.NewBasicExecutionContext(resource.Name, parameters, "")
+		execContext.GetMetadata().Set("mcpMethod", "readResource")
+		execContext.GetMetadata().Set("callTime", time.Now())
+
+		result, err := handler(ctx, execContext)
+		if err != nil {
+			return nil, err
+		}
+		if result.GetError() != nil {
+			return nil, result.GetError()
+		}
+
+		mimeType := resource.MIMEType
+		if actual, ok := result.GetMetadata().Get("mimeType"); ok {
+			mimeType, _ = actual.(string)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: mimeType,
+				Text:     result.GetContent(),
+			},
+		}, nil
+	}
+}
+
+// adaptPromptToMcpGo creates an mcp-go prompt handler rendering the prompt with the request arguments.
+func adaptPromptToMcpGo(prompt core.McpPrompt) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		messages, err := prompt.Render(request.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]mcp.PromptMessage, len(messages))
+		for i, message := range messages {
+			result[i] = mcp.NewPromptMessage(mcp.Role(message.Role), mcp.NewTextContent(message.Content))
+		}
+		return mcp.NewGetPromptResult(prompt.Description, result), nil
+	}
+}
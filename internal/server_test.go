@@ -16,7 +16,12 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -465,3 +470,175 @@ func (m *mockMakeMCPTool) ToMcpTool() core.McpTool {
 func (m *mockMakeMCPTool) ToJSON() string {
 	return `{"name":"mock_tool","description":"A mock tool for testing"}`
 }
+
+// mockResourceTool is a mock tool that is also exposed as resource template.
+type mockResourceTool struct {
+	mockMakeMCPTool
+}
+
+func (m *mockResourceTool) ToMcpResource() (core.McpResource, bool) {
+	return core.McpResource{
+		URI:        "https://api.example.com/users/{id}",
+		Name:       "mock_tool",
+		MIMEType:   "application/json",
+		IsTemplate: true,
+	}, true
+}
+
+func (m *mockResourceTool) GetResourceHandler() core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		id := request.GetParameters()["id"]
+		if id == "0" {
+			return core.NewBasicExecutionError(fmt.Errorf("HTTP 404")), nil
+		}
+		return core.NewBasicExecutionResult(fmt.Sprintf(`{"id": %q}`, id), nil), nil
+	}
+}
+
+func TestGetMCPServer_Resources(t *testing.T) {
+	app := &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools:   []core.MakeMCPTool{&mockResourceTool{}},
+	}
+	mcpServer := GetMCPServer(app)
+
+	send := func(message string) string {
+		response := mcpServer.HandleMessage(context.Background(), []byte(message))
+		data, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	templates := send(`{"jsonrpc": "2.0", "id": 1, "method": "resources/templates/list"}`)
+	if !strings.Contains(templates, `"uriTemplate":"https://api.example.com/users/{id}"`) {
+		t.Errorf("Expected resource template to be listed, got: %s", templates)
+	}
+
+	read := send(`{"jsonrpc": "2.0", "id": 2, "method": "resources/read", "params": {"uri": "https://api.example.com/users/7"}}`)
+	if !strings.Contains(read, `"text":"{\"id\": \"7\"}"`) || !strings.Contains(read, `"mimeType":"application/json"`) {
+		t.Errorf("Expected resource content with template variables resolved, got: %s", read)
+	}
+
+	failed := send(`{"jsonrpc": "2.0", "id": 3, "method": "resources/read", "params": {"uri": "https://api.example.com/users/0"}}`)
+	if !strings.Contains(failed, `"error"`) || !strings.Contains(failed, "HTTP 404") {
+		t.Errorf("Expected failed read to return an error, got: %s", failed)
+	}
+}
+
+func TestGetMCPServer_Prompts(t *testing.T) {
+	app := &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Prompts: []core.McpPrompt{{
+			Name:        "get_user_example",
+			Description: "Look up a user",
+			Arguments:   []core.McpPromptArgument{{Name: "path__id", Required: true}},
+			Messages:    []core.McpPromptMessage{{Role: "user", Content: "Call get_user with id {{path__id}}"}},
+		}},
+	}
+	mcpServer := GetMCPServer(app)
+
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	list := send(`{"jsonrpc": "2.0", "id": 1, "method": "prompts/list"}`)
+	if !strings.Contains(list, `"name":"get_user_example"`) || !strings.Contains(list, `"required":true`) {
+		t.Errorf("Expected prompt with required argument to be listed, got: %s", list)
+	}
+
+	get := send(`{"jsonrpc": "2.0", "id": 2, "method": "prompts/get", "params": {"name": "get_user_example", "arguments": {"path__id": "7"}}}`)
+	if !strings.Contains(get, "Call get_user with id 7") {
+		t.Errorf("Expected rendered prompt message, got: %s", get)
+	}
+
+	missing := send(`{"jsonrpc": "2.0", "id": 3, "method": "prompts/get", "params": {"name": "get_user_example"}}`)
+	if !strings.Contains(missing, "requires argument path__id") {
+		t.Errorf("Expected error for missing required argument, got: %s", missing)
+	}
+}
+
+func TestGetMCPServer_XMLResponseAsJSON(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<user><id>7</id><name>Jane</name></user>`))
+	}))
+	defer api.Close()
+
+	spec := filepath.Join(t.TempDir(), "openapi.yaml")
+	err := os.WriteFile(spec, []byte(`
+openapi: 3.0.0
+info:
+  title: Users API
+  version: 1.0.0
+paths:
+  /user:
+    get:
+      operationId: getUser
+      responses:
+        '200':
+          description: Success
+          content:
+            application/xml:
+              schema:
+                type: object
+`), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	source := openapi.NewOpenAPISource()
+	app, err := source.ParseSpec(spec)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+	app.AppParams.(*openapi.OpenAPIParams).BaseURL = api.URL
+	if err := source.AttachToolHandlers(app); err != nil {
+		t.Fatalf("Failed to attach tool handlers: %v", err)
+	}
+	mcpServer := GetMCPServer(app)
+
+	message := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "getuser", "arguments": {}}}`
+	data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+
+	var response struct {
+		Result struct {
+			Meta              map[string]any `json:"_meta"`
+			StructuredContent map[string]any `json:"structuredContent"`
+			Content           []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	content := response.Result.Content
+	if len(content) != 2 {
+		t.Fatalf("Expected the response text and its JSON representation, got: %s", data)
+	}
+	var structured map[string]any
+	if err := json.Unmarshal([]byte(content[1].Text), &structured); err != nil {
+		t.Fatalf("Expected JSON content, got %q: %v", content[1].Text, err)
+	}
+	user, ok := structured["user"].(map[string]any)
+	if !ok || user["name"] != "Jane" {
+		t.Errorf("Expected converted XML response, got: %s", content[1].Text)
+	}
+	if !reflect.DeepEqual(response.Result.StructuredContent, structured) {
+		t.Errorf("Expected structured content %v, got: %s", structured, data)
+	}
+	if _, exists := response.Result.Meta[core.MetadataStructuredContent]; exists {
+		t.Errorf("Expected structured content not to be repeated in _meta, got: %s", data)
+	}
+}
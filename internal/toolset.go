@@ -0,0 +1,244 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolSet manages the tools registered with the MCP server. Switching profiles and reloading
+// the app register only added or changed tool definitions and delete removed ones, mcp-go
+// notifies connected clients of these changes with notifications/tools/list_changed.
+// Registered tools dispatch to the current handler by name, so reloads also take effect
+// for tools whose definition did not change.
+type toolSet struct {
+	mcpServer *server.MCPServer
+
+	mu          sync.Mutex
+	app         *core.MakeMCPApp
+	active      string                             // Name of the enabled profile
+	definitions map[string]string                  // Registered tool name to its JSON definition
+	handlers    map[string]core.MakeMcpToolHandler // Registered tool name to its current handler
+}
+
+// toolSetChanges lists the tools changed by updating a tool set.
+type toolSetChanges struct {
+	added, updated, removed []string
+	enabled                 int // Number of tools of the enabled profile
+}
+
+// newToolSet creates the tool set of app, tools are registered by enable.
+func newToolSet(mcpServer *server.MCPServer, app *core.MakeMCPApp) *toolSet {
+	return &toolSet{
+		mcpServer:   mcpServer,
+		app:         app,
+		definitions: map[string]string{},
+		handlers:    map[string]core.MakeMcpToolHandler{},
+	}
+}
+
+// enable registers the tools of profile and removes all other tools.
+// In discovery mode, the meta-tools are registered again with an index of the profile's tools.
+func (s *toolSet) enable(profile string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes, err := s.update(s.app, profile)
+	return changes.enabled, err
+}
+
+// reload replaces the app, keeping the enabled profile unless it no longer exists.
+func (s *toolSet) reload(app *core.MakeMCPApp) (toolSetChanges, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profile := s.active
+	if _, err := app.SelectTools(profile); err != nil {
+		log.Printf("Profile %s no longer exists, enabling all tools", profile)
+		profile = core.AllToolsProfile
+	}
+	return s.update(app, profile)
+}
+
+// update registers the tools of profile of app, s.mu must be held.
+func (s *toolSet) update(app *core.MakeMCPApp, profile string) (toolSetChanges, error) {
+	tools, err := app.SelectTools(profile)
+	if err != nil {
+		return toolSetChanges{}, err
+	}
+	advertised := tools
+	if isDiscoveryMode(app) {
+		advertised = newDiscoveryTools(tools)
+		log.Printf("Discovery mode: indexed %d tools behind %d meta-tools", len(tools), len(advertised))
+	}
+	if len(app.Profiles) > 0 {
+		advertised = append(slices.Clip(advertised), s.newSwitchProfileTool(app))
+	}
+
+	changes := toolSetChanges{enabled: len(tools)}
+	definitions := make(map[string]string, len(advertised))
+	handlers := make(map[string]core.MakeMcpToolHandler, len(advertised))
+	var registered []server.ServerTool
+	for _, tool := range advertised {
+		mcpTool := tool.ToMcpTool()
+		mcpGoTool := toMcpGoTool(&mcpTool)
+		definition, err := json.Marshal(mcpGoTool)
+		if err != nil {
+			return toolSetChanges{}, fmt.Errorf("failed to encode tool %s: %w", mcpTool.Name, err)
+		}
+		definitions[mcpTool.Name] = string(definition)
+		handlers[mcpTool.Name] = tool.GetHandler()
+
+		previous, exists := s.definitions[mcpTool.Name]
+		switch {
+		case !exists:
+			changes.added = append(changes.added, mcpTool.Name)
+		case previous != string(definition):
+			changes.updated = append(changes.updated, mcpTool.Name)
+		default:
+			continue
+		}
+		// Adapt the transport-agnostic handler to work with mcp-go
+		registered = append(registered, server.ServerTool{Tool: mcpGoTool, Handler: adaptHandlerToMcpGo(s.dispatch(mcpTool.Name))})
+		log.Printf("Registered TOOL: %s with transport-agnostic handler (adapted)", mcpTool.Name)
+	}
+	for name := range s.definitions {
+		if _, ok := definitions[name]; !ok {
+			changes.removed = append(changes.removed, name)
+		}
+	}
+	slices.Sort(changes.removed)
+
+	s.app, s.active = app, profile
+	s.definitions, s.handlers = definitions, handlers
+	if len(changes.removed) > 0 {
+		s.mcpServer.DeleteTools(changes.removed...)
+	}
+	if len(registered) > 0 {
+		s.mcpServer.AddTools(registered...)
+	}
+	return changes, nil
+}
+
+// dispatch returns a handler calling the current handler of the named tool.
+func (s *toolSet) dispatch(name string) core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		s.mu.Lock()
+		handler := s.handlers[name]
+		s.mu.Unlock()
+		if handler == nil {
+			return core.NewBasicExecutionError(fmt.Errorf("tool %s is no longer available", name)), nil
+		}
+		return handler(ctx, request)
+	}
+}
+
+// contentSet manages the resources and prompts registered with the MCP server. Reloading the app
+// registers its resources and prompts again and deletes removed ones. Registered resources dispatch
+// to the current handler by URI like tools. mcp-go cannot delete resource templates, so templates
+// of removed operations stay listed and reading them returns an error.
+type contentSet struct {
+	mcpServer   *server.MCPServer
+	completions *CompletionHandler // Completion provider of the MCP server, nil if it does not support completion
+
+	mu        sync.Mutex
+	resources map[string]bool                    // Registered resource URI or URI template, true for templates
+	handlers  map[string]core.MakeMcpToolHandler // Resource URI or URI template to its current handler
+	prompts   map[string]bool                    // Registered prompt names
+}
+
+// newContentSet creates the content set of an MCP server, resources and prompts are registered by update.
+func newContentSet(mcpServer *server.MCPServer, completions *CompletionHandler) *contentSet {
+	return &contentSet{
+		mcpServer:   mcpServer,
+		completions: completions,
+		resources:   map[string]bool{},
+		handlers:    map[string]core.MakeMcpToolHandler{},
+		prompts:     map[string]bool{},
+	}
+}
+
+// update registers the resources and prompts of app, completed by their tools, and removes all others,
+// returning the number of resources and prompts of app.
+func (s *contentSet) update(app *core.MakeMCPApp) (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := make(map[string]bool, len(s.resources))
+	handlers := make(map[string]core.MakeMcpToolHandler)
+	for _, tool := range app.Tools {
+		// Tools may additionally be readable as resources
+		if provider, ok := tool.(core.ResourceProvider); ok {
+			if resource, exposed := provider.ToMcpResource(); exposed {
+				resources[resource.URI] = resource.IsTemplate
+				handlers[resource.URI] = provider.GetResourceHandler()
+				registerResource(s.mcpServer, resource, s.dispatch(resource.URI))
+			}
+		}
+	}
+	for uri, isTemplate := range s.resources {
+		if _, ok := resources[uri]; ok {
+			continue
+		}
+		if isTemplate {
+			resources[uri] = true
+			log.Printf("Resource template %s is no longer available", uri)
+			continue
+		}
+		s.mcpServer.RemoveResource(uri)
+	}
+
+	prompts := make(map[string]bool, len(app.Prompts))
+	for _, prompt := range app.Prompts {
+		s.mcpServer.AddPrompt(toMcpGoPrompt(prompt), adaptPromptToMcpGo(prompt))
+		prompts[prompt.Name] = true
+		log.Printf("Registered PROMPT: %s", prompt.Name)
+	}
+	var removed []string
+	for name := range s.prompts {
+		if !prompts[name] {
+			removed = append(removed, name)
+		}
+	}
+	if len(removed) > 0 {
+		s.mcpServer.DeletePrompts(removed...)
+	}
+
+	if s.completions != nil {
+		s.completions.update(app)
+	}
+
+	s.resources, s.handlers, s.prompts = resources, handlers, prompts
+	return len(handlers), len(prompts)
+}
+
+// dispatch returns a handler calling the current handler of the resource with the URI or URI template.
+func (s *contentSet) dispatch(uri string) core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		s.mu.Lock()
+		handler := s.handlers[uri]
+		s.mu.Unlock()
+		if handler == nil {
+			return core.NewBasicExecutionError(fmt.Errorf("resource %s is no longer available", uri)), nil
+		}
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const (
+	filePollInterval = time.Second      // How often local files are checked for changes
+	urlPollInterval  = 30 * time.Second // How often remote URLs are checked for changes
+	urlPollTimeout   = 30 * time.Second
+)
+
+// Watcher polls local files and remote URLs and reloads the app when one of them changes.
+// Files are compared by modification time and size, URLs are requested conditionally with
+// the last ETag or Last-Modified value and compared by content if the server supports neither.
+type Watcher struct {
+	locations []string
+	load      func() (*core.MakeMCPApp, error)
+	client    *http.Client
+
+	filePollInterval time.Duration
+	urlPollInterval  time.Duration
+}
+
+// locationState is the last seen version of a watched location.
+type locationState struct {
+	modTime      time.Time
+	size         int64
+	etag         string
+	lastModified string
+	digest       [sha256.Size]byte
+}
+
+// NewWatcher creates a watcher of locations, load creates the app with tool handlers attached.
+func NewWatcher(locations []string, load func() (*core.MakeMCPApp, error)) *Watcher {
+	return &Watcher{
+		locations:        locations,
+		load:             load,
+		client:           &http.Client{Timeout: urlPollTimeout},
+		filePollInterval: filePollInterval,
+		urlPollInterval:  urlPollInterval,
+	}
+}
+
+// GetWatchLocations returns the locations watched for params, if its source reads any.
+func GetWatchLocations(params core.AppParams) []string {
+	if watchable, ok := params.(core.WatchableParams); ok {
+		return watchable.GetWatchLocations()
+	}
+	return nil
+}
+
+// Run polls the locations until ctx is done. When a location changed, the app is loaded
+// again and passed to apply; if loading or applying fails, the current app is kept.
+func (w *Watcher) Run(ctx context.Context, apply func(*core.MakeMCPApp) error) {
+	states := make(map[string]locationState, len(w.locations))
+	for _, location := range w.locations {
+		state, _, err := w.check(ctx, location, locationState{})
+		if err != nil {
+			log.Printf("Failed to watch %s: %v", location, err)
+		}
+		states[location] = state
+		log.Printf("Watching %s for changes", location)
+	}
+
+	ticker := time.NewTicker(w.filePollInterval)
+	defer ticker.Stop()
+	lastURLPoll := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pollURLs := time.Since(lastURLPoll) >= w.urlPollInterval
+		if pollURLs {
+			lastURLPoll = time.Now()
+		}
+		var changed []string
+		for _, location := range w.locations {
+			if isURL(location) && !pollURLs {
+				continue
+			}
+			state, locationChanged, err := w.check(ctx, location, states[location])
+			if err != nil {
+				log.Printf("Failed to check %s for changes: %v", location, err)
+				continue
+			}
+			states[location] = state
+			if locationChanged {
+				changed = append(changed, location)
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		log.Printf("Detected changes in %s, reloading", strings.Join(changed, ", "))
+		app, err := w.load()
+		if err == nil {
+			err = apply(app)
+		}
+		if err != nil {
+			log.Printf("Failed to reload, keeping the current tools: %v", err)
+		}
+	}
+}
+
+// check returns the current state of location and whether it changed since previous.
+func (w *Watcher) check(ctx context.Context, location string, previous locationState) (locationState, bool, error) {
+	if isURL(location) {
+		return w.checkURL(ctx, location, previous)
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		return previous, false, err
+	}
+	state := locationState{modTime: info.ModTime(), size: info.Size()}
+	changed := !state.modTime.Equal(previous.modTime) || state.size != previous.size
+	return state, changed, nil
+}
+
+// checkURL requests url conditionally, a 304 Not Modified response means it did not change.
+func (w *Watcher) checkURL(ctx context.Context, url string, previous locationState) (locationState, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return previous, false, err
+	}
+	if previous.etag != "" {
+		request.Header.Set("If-None-Match", previous.etag)
+	}
+	if previous.lastModified != "" {
+		request.Header.Set("If-Modified-Since", previous.lastModified)
+	}
+	response, err := w.client.Do(request)
+	if err != nil {
+		return previous, false, err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+
+	if response.StatusCode == http.StatusNotModified {
+		return previous, false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return previous, false, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, response.Body); err != nil {
+		return previous, false, err
+	}
+	state := locationState{
+		etag:         response.Header.Get("ETag"),
+		lastModified: response.Header.Get("Last-Modified"),
+	}
+	copy(state.digest[:], hash.Sum(nil))
+	return state, state.digest != previous.digest, nil
+}
+
+// isURL returns true if location is a remote http(s) URL rather than a file path.
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
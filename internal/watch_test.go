@@ -0,0 +1,212 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// newTestWatcher returns a watcher polling quickly, counting loads.
+func newTestWatcher(locations []string, loads *atomic.Int32) *Watcher {
+	watcher := NewWatcher(locations, func() (*core.MakeMCPApp, error) {
+		loads.Add(1)
+		return &core.MakeMCPApp{Name: "reloaded"}, nil
+	})
+	watcher.filePollInterval = 10 * time.Millisecond
+	watcher.urlPollInterval = 10 * time.Millisecond
+	return watcher
+}
+
+// waitForReload waits for the next app passed to apply.
+func waitForReload(t *testing.T, reloads <-chan *core.MakeMCPApp) *core.MakeMCPApp {
+	t.Helper()
+	select {
+	case app := <-reloads:
+		return app
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for reload")
+		return nil
+	}
+}
+
+func TestWatcher_File(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(spec, []byte(`{"openapi": "3.0.0"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	var loads atomic.Int32
+	watcher := newTestWatcher([]string{spec}, &loads)
+	reloads := make(chan *core.MakeMCPApp, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx, func(app *core.MakeMCPApp) error {
+		reloads <- app
+		return nil
+	})
+
+	// Unchanged files are not reloaded
+	time.Sleep(50 * time.Millisecond)
+	if got := loads.Load(); got != 0 {
+		t.Fatalf("Expected no reload without changes, got %d", got)
+	}
+
+	if err := os.WriteFile(spec, []byte(`{"openapi": "3.1.0", "info": {}}`), 0o644); err != nil {
+		t.Fatalf("Failed to update spec: %v", err)
+	}
+	if app := waitForReload(t, reloads); app.Name != "reloaded" {
+		t.Errorf("Expected reloaded app, got %s", app.Name)
+	}
+}
+
+func TestWatcher_URL(t *testing.T) {
+	var version atomic.Int32
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(`{"openapi": "3.0.0", "version": ` + etag + `}`))
+	}))
+	defer server.Close()
+
+	var loads atomic.Int32
+	watcher := newTestWatcher([]string{server.URL + "/openapi.json"}, &loads)
+	reloads := make(chan *core.MakeMCPApp, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx, func(app *core.MakeMCPApp) error {
+		reloads <- app
+		return nil
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for notModified.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if notModified.Load() == 0 || loads.Load() != 0 {
+		t.Fatalf("Expected conditional requests without reload, got %d not modified and %d loads", notModified.Load(), loads.Load())
+	}
+
+	version.Store(1)
+	waitForReload(t, reloads)
+}
+
+func TestToolSet_Reload(t *testing.T) {
+	app := &core.MakeMCPApp{Name: "Test Server", Version: "1.0.0", Tools: newIndexedTools()}
+	mcpServer, tools, _ := newMCPServer(app, true)
+
+	reloaded := &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools: []core.MakeMCPTool{
+			&mockIndexedTool{name: "listbuckets", description: "Returns a list of all buckets.", tags: []string{"Bucket"}},
+			&mockIndexedTool{name: "putobject", description: "Uploads an object.", tags: []string{"Object"}},
+			&mockIndexedTool{name: "getobject", description: "Downloads an object.", tags: []string{"Object"}},
+		},
+	}
+	changes, err := tools.reload(reloaded)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(changes.added) != 1 || changes.added[0] != "getobject" {
+		t.Errorf("Expected getobject to be added, got %v", changes.added)
+	}
+	if len(changes.updated) != 1 || changes.updated[0] != "putobject" {
+		t.Errorf("Expected putobject to be updated, got %v", changes.updated)
+	}
+	if len(changes.removed) != 1 || changes.removed[0] != "deletebucket" {
+		t.Errorf("Expected deletebucket to be removed, got %v", changes.removed)
+	}
+
+	data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`)))
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	list := string(data)
+	if strings.Contains(list, "deletebucket") || !strings.Contains(list, `"name":"getobject"`) || !strings.Contains(list, "Uploads an object.") {
+		t.Errorf("Expected the server's tools to match the reloaded app, got: %s", list)
+	}
+}
+
+// mockStatusTool is a mock tool exposed as resource without template variables.
+type mockStatusTool struct {
+	mockMakeMCPTool
+}
+
+func (m *mockStatusTool) ToMcpResource() (core.McpResource, bool) {
+	return core.McpResource{URI: "https://api.example.com/status", Name: "status", MIMEType: "application/json"}, true
+}
+
+func (m *mockStatusTool) GetResourceHandler() core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		return core.NewBasicExecutionResult(`{"status": "ok"}`, nil), nil
+	}
+}
+
+func TestContentSet_Reload(t *testing.T) {
+	app := newCompletionApp()
+	mcpServer, _, contents := newMCPServer(app, true)
+	send := func(message string) string {
+		data, err := json.Marshal(mcpServer.HandleMessage(context.Background(), []byte(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(data)
+	}
+
+	reloaded := &core.MakeMCPApp{
+		Name:    "Test Server",
+		Version: "1.0.0",
+		Tools:   []core.MakeMCPTool{&mockStatusTool{}},
+		Prompts: []core.McpPrompt{{Name: "status_example", Description: "Check the status"}},
+	}
+	resources, prompts := contents.update(reloaded)
+	if resources != 1 || prompts != 1 {
+		t.Errorf("Expected 1 resource and 1 prompt, got %d and %d", resources, prompts)
+	}
+
+	if read := send(`{"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": {"uri": "https://api.example.com/status"}}`); !strings.Contains(read, `\"status\": \"ok\"`) {
+		t.Errorf("Expected added resource to be readable, got: %s", read)
+	}
+	if read := send(`{"jsonrpc": "2.0", "id": 2, "method": "resources/read", "params": {"uri": "https://api.example.com/users/7"}}`); !strings.Contains(read, "no longer available") {
+		t.Errorf("Expected removed resource template to fail, got: %s", read)
+	}
+	if list := send(`{"jsonrpc": "2.0", "id": 3, "method": "prompts/list"}`); strings.Contains(list, "get_user_example") || !strings.Contains(list, "status_example") {
+		t.Errorf("Expected the server's prompts to match the reloaded app, got: %s", list)
+	}
+
+	completion := send(`{"jsonrpc": "2.0", "id": 4, "method": "completion/complete", ` +
+		`"params": {"ref": {"type": "ref/prompt", "name": "get_user_example"}, "argument": {"name": "path__id", "value": "al"}}}`)
+	if !strings.Contains(completion, `"values":[]`) {
+		t.Errorf("Expected removed prompt not to be completed, got %s", completion)
+	}
+}
@@ -17,20 +17,36 @@ package core
 // MakeMCPApp holds all information about the MCP server.
 // Main data structure representing a complete MCP application configuration
 type MakeMCPApp struct {
-	Name       string        `json:"name"`       // Name of the App
-	Version    string        `json:"version"`    // Version of the app
-	SourceType string        `json:"sourceType"` // Type of source (openapi, cli, etc.)
-	Tools      []MakeMCPTool `json:"tools"`      // Tools the MCP server will provide
-	AppParams  AppParams     `json:"config"`     // Source-specific parameters
+	SchemaVersion  int             `json:"schemaVersion"`            // Version of the config file format
+	Name           string          `json:"name"`                     // Name of the App
+	Version        string          `json:"version"`                  // Version of the app
+	SourceType     string          `json:"sourceType"`               // Type of source (openapi, cli, etc.)
+	Tools          []MakeMCPTool   `json:"tools"`                    // Tools the MCP server will provide
+	CompositeTools []CompositeTool `json:"compositeTools,omitempty"` // Tools chaining calls of other tools
+	Prompts        []McpPrompt     `json:"prompts,omitempty"`        // Prompts the MCP server will provide
+	Profiles       []ToolProfile   `json:"profiles,omitempty"`       // Named subsets of the tools
+	AppParams      AppParams       `json:"config"`                   // Source-specific parameters
+
+	References []ConfigReference `json:"-"` // Values resolved from references when loading the config file
+	FileParams AppParams         `json:"-"` // Params as read from the config file, saved instead of AppParams if set
+}
+
+// ConfigReference is a value of a config file resolved from a reference like ${API_TOKEN} when loading,
+// which is saved as the reference again so secrets are never written to the config file.
+type ConfigReference struct {
+	Path     []string // Keys and sequence indexes leading to the value
+	Raw      string   // Value in the config file
+	Resolved string   // Value after resolving the reference
 }
 
 // NewMakeMCPApp creates a new MakeMCPApp with provided parameters.
 func NewMakeMCPApp(name, version string, appParams AppParams) MakeMCPApp {
 	return MakeMCPApp{
-		Name:       name,
-		Version:    version,
-		SourceType: appParams.GetSourceType(),
-		Tools:      []MakeMCPTool{},
-		AppParams:  appParams,
+		SchemaVersion: CurrentSchemaVersion,
+		Name:          name,
+		Version:       version,
+		SourceType:    appParams.GetSourceType(),
+		Tools:         []MakeMCPTool{},
+		AppParams:     appParams,
 	}
 }
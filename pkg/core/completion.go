@@ -0,0 +1,29 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "context"
+
+// MaxCompletionValues is the maximum number of values a completion response may contain.
+const MaxCompletionValues = 100
+
+// CompletionProvider is implemented by tools that can suggest values for their arguments,
+// e.g. from schema enums or by querying the source. Transports use it to complete prompt
+// arguments of prompts calling the tool and variables of the tool's resource template.
+type CompletionProvider interface {
+	// CompleteArgument returns the known values of the named argument starting with value.
+	// argument is either a tool parameter or a variable of the tool's resource template.
+	CompleteArgument(ctx context.Context, argument, value string) ([]string, error)
+}
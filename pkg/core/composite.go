@@ -0,0 +1,68 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CompositeTool describes a tool that calls other tools of the app in sequence.
+// It is defined in the "compositeTools" section of the configuration file.
+// Step arguments may contain templates referring to the tool input and previous steps,
+// e.g. "{{inputs.user_id}}" or "{{steps.get_user.body.id}}".
+type CompositeTool struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	InputSchema McpToolInputSchema `json:"inputSchema"`
+	Steps       []CompositeStep    `json:"steps"`
+	Outputs     map[string]string  `json:"outputs,omitempty"` // Output name to template, defaults to the body of each step
+}
+
+// CompositeStep is a single tool call of a composite tool.
+type CompositeStep struct {
+	ID        string         `json:"id,omitempty"` // Defaults to the name of the called tool
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"` // Tool parameters, values may contain templates
+}
+
+// StepID returns the ID other steps use to refer to the result of this step.
+func (s CompositeStep) StepID() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return s.Tool
+}
+
+// Validate checks that the composite tool has a name and uniquely identified steps.
+func (c CompositeTool) Validate() error {
+	if c.Name == "" {
+		return errors.New("composite tool name is required")
+	}
+	if len(c.Steps) == 0 {
+		return fmt.Errorf("composite tool %s has no steps", c.Name)
+	}
+	seen := make(map[string]bool, len(c.Steps))
+	for i, step := range c.Steps {
+		if step.Tool == "" {
+			return fmt.Errorf("step %d of composite tool %s has no tool", i+1, c.Name)
+		}
+		if seen[step.StepID()] {
+			return fmt.Errorf("composite tool %s has duplicate step id %s", c.Name, step.StepID())
+		}
+		seen[step.StepID()] = true
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompositeTool_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    CompositeTool
+		wantErr string
+	}{
+		{
+			name: "valid",
+			tool: CompositeTool{Name: "c", Steps: []CompositeStep{{Tool: "a"}, {ID: "second", Tool: "a"}}},
+		},
+		{
+			name:    "missing name",
+			tool:    CompositeTool{Steps: []CompositeStep{{Tool: "a"}}},
+			wantErr: "name is required",
+		},
+		{
+			name:    "no steps",
+			tool:    CompositeTool{Name: "c"},
+			wantErr: "has no steps",
+		},
+		{
+			name:    "missing tool",
+			tool:    CompositeTool{Name: "c", Steps: []CompositeStep{{ID: "a"}}},
+			wantErr: "has no tool",
+		},
+		{
+			name:    "duplicate default step id",
+			tool:    CompositeTool{Name: "c", Steps: []CompositeStep{{Tool: "a"}, {Tool: "a"}}},
+			wantErr: "duplicate step id a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tool.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
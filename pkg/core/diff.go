@@ -0,0 +1,199 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ToolChangeKind classifies a difference between two versions of a tool set.
+type ToolChangeKind string
+
+const (
+	ToolAdded            ToolChangeKind = "tool_added"
+	ToolRemoved          ToolChangeKind = "tool_removed"
+	EndpointChanged      ToolChangeKind = "endpoint_changed"
+	ParameterAdded       ToolChangeKind = "parameter_added"
+	ParameterRemoved     ToolChangeKind = "parameter_removed"
+	ParameterRequired    ToolChangeKind = "parameter_required"
+	ParameterOptional    ToolChangeKind = "parameter_optional"
+	ParameterTypeChanged ToolChangeKind = "parameter_type_changed"
+	DescriptionChanged   ToolChangeKind = "description_changed"
+)
+
+// ToolChange is a single difference between a saved and a current tool.
+// Breaking changes are those that make existing tool calls fail or behave differently.
+type ToolChange struct {
+	Tool      string         `json:"tool"`
+	Kind      ToolChangeKind `json:"kind"`
+	Parameter string         `json:"parameter,omitempty"`
+	Detail    string         `json:"detail"`
+	Breaking  bool           `json:"breaking"`
+}
+
+// String returns a human-readable description of the change.
+func (c ToolChange) String() string {
+	prefix := "  "
+	if c.Breaking {
+		prefix = "! "
+	}
+	return fmt.Sprintf("%s%s: %s", prefix, c.Tool, c.Detail)
+}
+
+// EndpointTool is implemented by tools calling a fixed endpoint, e.g. the HTTP method and path of an API operation.
+// Comparing tool sets reports a changed endpoint as breaking change.
+type EndpointTool interface {
+	GetEndpoint() string
+}
+
+// CompareTools returns the differences between the saved and the current tools, sorted by tool name.
+// Tools are matched by name; added tools and optional parameters are not breaking.
+func CompareTools(saved, current []MakeMCPTool) []ToolChange {
+	savedByName := make(map[string]MakeMCPTool, len(saved))
+	for _, tool := range saved {
+		savedByName[tool.GetName()] = tool
+	}
+	currentByName := make(map[string]MakeMCPTool, len(current))
+	for _, tool := range current {
+		currentByName[tool.GetName()] = tool
+	}
+
+	var changes []ToolChange
+	for name := range savedByName {
+		if _, ok := currentByName[name]; !ok {
+			changes = append(changes, ToolChange{Tool: name, Kind: ToolRemoved, Detail: "tool was removed", Breaking: true})
+		}
+	}
+	for name, tool := range currentByName {
+		previous, ok := savedByName[name]
+		if !ok {
+			changes = append(changes, ToolChange{Tool: name, Kind: ToolAdded, Detail: "tool was added"})
+			continue
+		}
+		changes = append(changes, compareTool(previous, tool)...)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Tool != changes[j].Tool {
+			return changes[i].Tool < changes[j].Tool
+		}
+		return changes[i].Parameter < changes[j].Parameter
+	})
+	return changes
+}
+
+// HasBreakingChanges returns true if any of changes is breaking.
+func HasBreakingChanges(changes []ToolChange) bool {
+	return slices.ContainsFunc(changes, func(c ToolChange) bool { return c.Breaking })
+}
+
+// compareTool returns the differences between two versions of the same tool.
+func compareTool(saved, current MakeMCPTool) []ToolChange {
+	name := current.GetName()
+	var changes []ToolChange
+
+	savedEndpoint, currentEndpoint := getEndpoint(saved), getEndpoint(current)
+	if savedEndpoint != currentEndpoint {
+		changes = append(changes, ToolChange{
+			Tool:     name,
+			Kind:     EndpointChanged,
+			Detail:   fmt.Sprintf("endpoint changed from %s to %s", savedEndpoint, currentEndpoint),
+			Breaking: true,
+		})
+	}
+
+	savedTool, currentTool := saved.ToMcpTool(), current.ToMcpTool()
+	if savedTool.Description != currentTool.Description {
+		changes = append(changes, ToolChange{Tool: name, Kind: DescriptionChanged, Detail: "description changed"})
+	}
+
+	savedSchema, currentSchema := savedTool.InputSchema, currentTool.InputSchema
+	for param := range savedSchema.Properties {
+		if _, ok := currentSchema.Properties[param]; !ok {
+			changes = append(changes, ToolChange{
+				Tool: name, Kind: ParameterRemoved, Parameter: param,
+				Detail: fmt.Sprintf("parameter %s was removed", param), Breaking: true,
+			})
+		}
+	}
+	for param, property := range currentSchema.Properties {
+		required := slices.Contains(currentSchema.Required, param)
+		savedProperty, ok := savedSchema.Properties[param]
+		if !ok {
+			detail := fmt.Sprintf("optional parameter %s was added", param)
+			if required {
+				detail = fmt.Sprintf("required parameter %s was added", param)
+			}
+			changes = append(changes, ToolChange{Tool: name, Kind: ParameterAdded, Parameter: param, Detail: detail, Breaking: required})
+			continue
+		}
+
+		wasRequired := slices.Contains(savedSchema.Required, param)
+		switch {
+		case required && !wasRequired:
+			changes = append(changes, ToolChange{
+				Tool: name, Kind: ParameterRequired, Parameter: param,
+				Detail: fmt.Sprintf("parameter %s became required", param), Breaking: true,
+			})
+		case !required && wasRequired:
+			changes = append(changes, ToolChange{
+				Tool: name, Kind: ParameterOptional, Parameter: param,
+				Detail: fmt.Sprintf("parameter %s became optional", param),
+			})
+		}
+
+		if savedType, currentType := getPropertyType(savedProperty), getPropertyType(property); savedType != currentType {
+			changes = append(changes, ToolChange{
+				Tool: name, Kind: ParameterTypeChanged, Parameter: param,
+				Detail: fmt.Sprintf("type of parameter %s changed from %s to %s", param, savedType, currentType), Breaking: true,
+			})
+		}
+	}
+	return changes
+}
+
+// getEndpoint returns the endpoint of tool, or an empty string if it has none.
+func getEndpoint(tool MakeMCPTool) string {
+	if endpoint, ok := tool.(EndpointTool); ok {
+		return endpoint.GetEndpoint()
+	}
+	return ""
+}
+
+// getPropertyType returns the JSON Schema type of a property, including the item type of arrays.
+func getPropertyType(property any) string {
+	schema, ok := property.(map[string]any)
+	if !ok {
+		return "any"
+	}
+	var propertyType string
+	switch value := schema["type"].(type) {
+	case nil:
+		propertyType = "any"
+	case string:
+		propertyType = value
+	default:
+		// Type unions, e.g. ["string", "null"]
+		encoded, _ := json.Marshal(value)
+		propertyType = string(encoded)
+	}
+	if propertyType == "array" {
+		propertyType += "<" + getPropertyType(schema["items"]) + ">"
+	}
+	return propertyType
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+)
+
+// mockEndpointTool is a mock tool with an input schema and an endpoint.
+type mockEndpointTool struct {
+	name     string
+	endpoint string
+	schema   McpToolInputSchema
+}
+
+func (m *mockEndpointTool) GetName() string                { return m.name }
+func (m *mockEndpointTool) GetHandler() MakeMcpToolHandler { return nil }
+func (m *mockEndpointTool) GetEndpoint() string            { return m.endpoint }
+func (m *mockEndpointTool) ToMcpTool() McpTool {
+	return McpTool{Name: m.name, InputSchema: m.schema}
+}
+
+func TestCompareTools(t *testing.T) {
+	saved := []MakeMCPTool{
+		&mockEndpointTool{name: "getpet", endpoint: "GET /pets/{id}", schema: McpToolInputSchema{
+			Properties: map[string]any{
+				"path__id":      map[string]any{"type": "string"},
+				"query__fields": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				"query__expand": map[string]any{"type": "boolean"},
+				"query__limit":  map[string]any{"type": "integer"},
+			},
+			Required: []string{"path__id", "query__limit"},
+		}},
+		&mockEndpointTool{name: "deletepet", endpoint: "DELETE /pets/{id}"},
+		&mockEndpointTool{name: "updatepet", endpoint: "PUT /pets/{id}"},
+	}
+	current := []MakeMCPTool{
+		&mockEndpointTool{name: "getpet", endpoint: "GET /pets/{id}", schema: McpToolInputSchema{
+			Properties: map[string]any{
+				"path__id":      map[string]any{"type": "integer"},
+				"query__fields": map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+				"query__limit":  map[string]any{"type": "integer"},
+				"query__tenant": map[string]any{"type": "string"},
+				"query__sort":   map[string]any{"type": "string"},
+			},
+			Required: []string{"path__id", "query__tenant"},
+		}},
+		&mockEndpointTool{name: "updatepet", endpoint: "PATCH /pets/{id}"},
+		&mockEndpointTool{name: "listpets", endpoint: "GET /pets"},
+	}
+
+	changes := CompareTools(saved, current)
+
+	want := []ToolChange{
+		{Tool: "deletepet", Kind: ToolRemoved, Breaking: true},
+		{Tool: "getpet", Kind: ParameterTypeChanged, Parameter: "path__id", Breaking: true},
+		{Tool: "getpet", Kind: ParameterRemoved, Parameter: "query__expand", Breaking: true},
+		{Tool: "getpet", Kind: ParameterTypeChanged, Parameter: "query__fields", Breaking: true},
+		{Tool: "getpet", Kind: ParameterOptional, Parameter: "query__limit"},
+		{Tool: "getpet", Kind: ParameterAdded, Parameter: "query__sort"},
+		{Tool: "getpet", Kind: ParameterAdded, Parameter: "query__tenant", Breaking: true},
+		{Tool: "listpets", Kind: ToolAdded},
+		{Tool: "updatepet", Kind: EndpointChanged, Breaking: true},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(want), len(changes), changes)
+	}
+	for i, change := range changes {
+		if change.Tool != want[i].Tool || change.Kind != want[i].Kind || change.Parameter != want[i].Parameter || change.Breaking != want[i].Breaking {
+			t.Errorf("Change %d: expected %+v, got %+v", i, want[i], change)
+		}
+	}
+	if !HasBreakingChanges(changes) {
+		t.Error("Expected breaking changes")
+	}
+
+	if changes := CompareTools(saved, saved); len(changes) != 0 || HasBreakingChanges(changes) {
+		t.Errorf("Expected no changes comparing tools with themselves, got %v", changes)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxDescriptionLength is the description length above which tools are reported as overly long.
+	MaxDescriptionLength = 1024
+	// MaxToolListTokens is the estimated token size of the tool list above which it is reported as too large.
+	MaxToolListTokens = 20000
+	// charactersPerToken approximates the token count of JSON tool definitions.
+	charactersPerToken = 4
+)
+
+// validToolName matches tool names accepted by MCP clients.
+var validToolName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// LintSeverity is the severity of a lint issue, errors make tools unusable for some clients.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem of a tool or the tool list affecting its use over MCP.
+type LintIssue struct {
+	Severity  LintSeverity `json:"severity"`
+	Rule      string       `json:"rule"`
+	Tool      string       `json:"tool,omitempty"`
+	Parameter string       `json:"parameter,omitempty"`
+	Message   string       `json:"message"`
+}
+
+// String returns a human-readable description of the issue.
+func (i LintIssue) String() string {
+	subject := i.Tool
+	if i.Parameter != "" {
+		subject += "." + i.Parameter
+	}
+	if subject == "" {
+		return fmt.Sprintf("%-7s %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%-7s %s: %s", i.Severity, subject, i.Message)
+}
+
+// LintableTool is implemented by tools reporting source-specific issues, e.g. request bodies without schema.
+type LintableTool interface {
+	Lint() []LintIssue
+}
+
+// LintReport is the result of linting a tool list.
+type LintReport struct {
+	Tools           int         `json:"tools"`
+	EstimatedTokens int         `json:"estimatedTokens"` // Approximate token size of the tool list sent to clients
+	Issues          []LintIssue `json:"issues"`
+}
+
+// Count returns the number of issues with the given severity.
+func (r LintReport) Count(severity LintSeverity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// LintTools checks tools for problems exposing them over MCP: invalid and duplicate names,
+// missing or overly long descriptions, source-specific issues and the size of the tool list.
+func LintTools(tools []MakeMCPTool) LintReport {
+	report := LintReport{Tools: len(tools), Issues: []LintIssue{}}
+	seen := make(map[string]bool, len(tools))
+	definitions := make([]McpTool, 0, len(tools))
+	for _, tool := range tools {
+		mcpTool := tool.ToMcpTool()
+		definitions = append(definitions, mcpTool)
+		name := mcpTool.Name
+
+		if !validToolName.MatchString(name) {
+			report.Issues = append(report.Issues, LintIssue{
+				Severity: LintError, Rule: "invalid_name", Tool: name,
+				Message: "tool names must be 1 to 64 characters of letters, digits, '_' and '-'",
+			})
+		}
+		if seen[name] {
+			report.Issues = append(report.Issues, LintIssue{
+				Severity: LintError, Rule: "duplicate_name", Tool: name,
+				Message: "tool name is used by several tools, only one of them is reachable",
+			})
+		}
+		seen[name] = true
+
+		switch {
+		case mcpTool.Description == "":
+			report.Issues = append(report.Issues, LintIssue{
+				Severity: LintWarning, Rule: "missing_description", Tool: name,
+				Message: "tool has no description, models can only guess its purpose from the name",
+			})
+		case len(mcpTool.Description) > MaxDescriptionLength:
+			report.Issues = append(report.Issues, LintIssue{
+				Severity: LintWarning, Rule: "long_description", Tool: name,
+				Message: fmt.Sprintf("description has %d characters, more than %d use up context", len(mcpTool.Description), MaxDescriptionLength),
+			})
+		}
+
+		if lintable, ok := tool.(LintableTool); ok {
+			report.Issues = append(report.Issues, lintable.Lint()...)
+		}
+	}
+
+	// Tool definitions are sent to clients as JSON
+	if encoded, err := json.Marshal(definitions); err == nil {
+		report.EstimatedTokens = len(encoded) / charactersPerToken
+	}
+	if report.EstimatedTokens > MaxToolListTokens {
+		report.Issues = append(report.Issues, LintIssue{
+			Severity: LintWarning, Rule: "tool_list_size",
+			Message: fmt.Sprintf("the tool list has about %d tokens, consider tool profiles or discovery mode to stay below %d", report.EstimatedTokens, MaxToolListTokens),
+		})
+	}
+
+	// Errors first
+	slices.SortStableFunc(report.Issues, func(a, b LintIssue) int {
+		return strings.Compare(string(a.Severity), string(b.Severity))
+	})
+	return report
+}
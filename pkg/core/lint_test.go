@@ -0,0 +1,86 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mockLintableTool is a mockTool reporting a fixed issue.
+type mockLintableTool struct {
+	mockTool
+}
+
+func (m *mockLintableTool) Lint() []LintIssue {
+	return []LintIssue{{Severity: LintWarning, Rule: "custom", Tool: m.name, Message: "custom issue"}}
+}
+
+func TestLintTools(t *testing.T) {
+	tools := []MakeMCPTool{
+		&mockTool{name: "get_user", description: "Get a user"},
+		&mockTool{name: "get user", description: "Get a user"},
+		&mockTool{name: "get_user", description: "Get a user again"},
+		&mockTool{name: "list_users"},
+		&mockTool{name: "search_users", description: strings.Repeat("x", MaxDescriptionLength+1)},
+		&mockLintableTool{mockTool: mockTool{name: "custom", description: "Custom tool"}},
+	}
+
+	report := LintTools(tools)
+
+	want := []struct {
+		severity LintSeverity
+		rule     string
+		tool     string
+	}{
+		{LintError, "invalid_name", "get user"},
+		{LintError, "duplicate_name", "get_user"},
+		{LintWarning, "missing_description", "list_users"},
+		{LintWarning, "long_description", "search_users"},
+		{LintWarning, "custom", "custom"},
+	}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(want), len(report.Issues), report.Issues)
+	}
+	for i, issue := range report.Issues {
+		if issue.Severity != want[i].severity || issue.Rule != want[i].rule || issue.Tool != want[i].tool {
+			t.Errorf("Issue %d: expected %+v, got %+v", i, want[i], issue)
+		}
+	}
+	if report.Tools != len(tools) || report.EstimatedTokens == 0 {
+		t.Errorf("Expected tool count and token estimate, got %d tools and %d tokens", report.Tools, report.EstimatedTokens)
+	}
+	if report.Count(LintError) != 2 || report.Count(LintWarning) != 3 {
+		t.Errorf("Unexpected issue counts: %d errors, %d warnings", report.Count(LintError), report.Count(LintWarning))
+	}
+}
+
+func TestLintTools_ToolListSize(t *testing.T) {
+	description := strings.Repeat("x", MaxDescriptionLength)
+	var tools []MakeMCPTool
+	for len(tools)*MaxDescriptionLength < MaxToolListTokens*charactersPerToken {
+		tools = append(tools, &mockTool{name: fmt.Sprintf("tool_%d", len(tools)), description: description})
+	}
+
+	report := LintTools(tools)
+	found := false
+	for _, issue := range report.Issues {
+		found = found || issue.Rule == "tool_list_size"
+	}
+	if !found {
+		t.Errorf("Expected tool list of about %d tokens to be reported as too large", report.EstimatedTokens)
+	}
+}
@@ -83,3 +83,9 @@ type MakeMCPTool interface {
 	GetHandler() MakeMcpToolHandler
 	ToMcpTool() McpTool
 }
+
+// TaggedTool is implemented by tools grouped by tags, e.g. the tags of an OpenAPI operation.
+// Discovery mode uses the tags when searching tools.
+type TaggedTool interface {
+	GetTags() []string
+}
@@ -56,8 +56,25 @@ type ToolExecutionContext interface {
 
 	// GetMetadata returns transport-agnostic metadata about the request
 	GetMetadata() Metadata
+
+	// GetProgressNotifier returns the notifier used to report incremental progress to the caller
+	GetProgressNotifier() ProgressNotifier
+}
+
+// ProgressNotifier allows tool handlers to send incremental updates while they are running.
+// Transports decide how updates are delivered (e.g. MCP progress or log notifications).
+type ProgressNotifier interface {
+	// NotifyProgress reports progress of the running tool call.
+	// total is 0 if unknown, message carries optional human-readable or streamed content.
+	NotifyProgress(progress, total float64, message string) error
 }
 
+// NoopProgressNotifier discards all progress updates.
+type NoopProgressNotifier struct{}
+
+// NotifyProgress discards the progress update.
+func (NoopProgressNotifier) NotifyProgress(progress, total float64, message string) error { return nil }
+
 // ToolExecutionResult represents the result of tool execution with rich metadata.
 // This interface allows processors to understand and transform results appropriately.
 type ToolExecutionResult interface {
@@ -71,6 +88,10 @@ type ToolExecutionResult interface {
 	GetMetadata() Metadata
 }
 
+// MetadataStructuredContent is the metadata key of a JSON representation of a result,
+// which is sent to the client as structured content and as additional text content.
+const MetadataStructuredContent = "structuredContent"
+
 // Metadata provides context about the tool execution request.
 // Each source type can implement this interface with only the fields that make sense.
 type Metadata interface {
@@ -106,6 +127,7 @@ type BasicExecutionContext struct {
 	toolName   string
 	parameters map[string]any
 	metadata   Metadata
+	notifier   ProgressNotifier
 }
 
 // NewBasicExecutionContext creates a new BasicExecutionContext.
@@ -114,6 +136,7 @@ func NewBasicExecutionContext(toolName string, parameters map[string]any, reques
 		toolName:   toolName,
 		parameters: parameters,
 		metadata:   NewBasicMetadata(""),
+		notifier:   NoopProgressNotifier{},
 	}
 }
 
@@ -126,6 +149,17 @@ func (b *BasicExecutionContext) GetParameters() map[string]any { return b.parame
 // GetMetadata returns the execution metadata.
 func (b *BasicExecutionContext) GetMetadata() Metadata { return b.metadata }
 
+// GetProgressNotifier returns the progress notifier, never nil.
+func (b *BasicExecutionContext) GetProgressNotifier() ProgressNotifier {
+	if b.notifier == nil {
+		return NoopProgressNotifier{}
+	}
+	return b.notifier
+}
+
+// SetProgressNotifier sets the notifier used to report progress for this execution.
+func (b *BasicExecutionContext) SetProgressNotifier(notifier ProgressNotifier) { b.notifier = notifier }
+
 // BasicExecutionResult is a simple implementation of ToolExecutionResult.
 // Uses the Metadata interface for consistency with execution context.
 type BasicExecutionResult struct {
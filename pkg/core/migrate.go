@@ -0,0 +1,126 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// CurrentSchemaVersion is the version of the config file format written by SaveToFile.
+// Increase it together with registering a migration from the previous version.
+const CurrentSchemaVersion = 1
+
+// ConfigDocument is a decoded config file, with numbers kept as json.Number.
+type ConfigDocument map[string]any
+
+// ConfigMigration upgrades config documents of schema version From to From+1.
+type ConfigMigration struct {
+	From        int
+	Description string
+	Migrate     func(document ConfigDocument) error
+}
+
+var (
+	migrationsMu sync.RWMutex
+
+	// configMigrations holds the migrations by the schema version they upgrade from
+	configMigrations = map[int]ConfigMigration{
+		0: {
+			From:        0,
+			Description: "remove the raw CLI flags and arguments saved by early versions",
+			Migrate:     migrateRawCLIInput,
+		},
+	}
+)
+
+// RegisterConfigMigration adds a migration to the registry, e.g. for source-specific format changes.
+// It panics if a migration from the same version is already registered.
+func RegisterConfigMigration(migration ConfigMigration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	if _, exists := configMigrations[migration.From]; exists {
+		panic(fmt.Sprintf("config migration from schema version %d is already registered", migration.From))
+	}
+	configMigrations[migration.From] = migration
+}
+
+// GetSchemaVersion returns the schema version of a config file, 0 for files written before versioning.
+func GetSchemaVersion(data []byte) (int, error) {
+	var header struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, fmt.Errorf("failed to decode schema version: %w", err)
+	}
+	return header.SchemaVersion, nil
+}
+
+// MigrateConfig upgrades a JSON config file step by step to CurrentSchemaVersion,
+// returning the migrated config and the applied migrations. Current configs are returned unchanged.
+func MigrateConfig(data []byte) ([]byte, []ConfigMigration, error) {
+	version, err := GetSchemaVersion(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version == CurrentSchemaVersion {
+		return data, nil, nil
+	}
+	if version > CurrentSchemaVersion || version < 0 {
+		return nil, nil, fmt.Errorf("unsupported schema version %d, this version of MakeMCP supports up to %d",
+			version, CurrentSchemaVersion)
+	}
+
+	var document ConfigDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+	var applied []ConfigMigration
+	for ; version < CurrentSchemaVersion; version++ {
+		migration, exists := configMigrations[version]
+		if !exists {
+			return nil, nil, fmt.Errorf("no migration from schema version %d registered", version)
+		}
+		if err := migration.Migrate(document); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate config from schema version %d: %w", version, err)
+		}
+		applied = append(applied, migration)
+	}
+	document["schemaVersion"] = CurrentSchemaVersion
+
+	migrated, err := json.Marshal(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode migrated config: %w", err)
+	}
+	return migrated, applied, nil
+}
+
+// migrateRawCLIInput removes the flags and args fields, which early versions saved in the config section.
+func migrateRawCLIInput(document ConfigDocument) error {
+	config, ok := document["config"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	delete(config, "flags")
+	delete(config, "args")
+	return nil
+}
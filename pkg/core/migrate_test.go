@@ -0,0 +1,113 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          string
+		expectError     string
+		expectMigrated  int
+		expectUnchanged bool
+	}{
+		{
+			name:            "Current version",
+			config:          `{"schemaVersion": 1, "name": "api", "config": {"flags": {}}}`,
+			expectUnchanged: true,
+		},
+		{
+			name:           "Unversioned config",
+			config:         `{"name": "api", "config": {"timeout": 30, "maxResponseSize": 9007199254740993, "flags": {}, "args": []}}`,
+			expectMigrated: 1,
+		},
+		{
+			name:           "Unversioned config without config section",
+			config:         `{"name": "api"}`,
+			expectMigrated: 1,
+		},
+		{
+			name:        "Newer version",
+			config:      `{"schemaVersion": 2}`,
+			expectError: "unsupported schema version 2",
+		},
+		{
+			name:        "Invalid version",
+			config:      `{"schemaVersion": "1"}`,
+			expectError: "failed to decode schema version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, migrations, err := MigrateConfig([]byte(tt.config))
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(migrations) != tt.expectMigrated {
+				t.Errorf("Expected %d migrations, got %d", tt.expectMigrated, len(migrations))
+			}
+			if tt.expectUnchanged {
+				if string(migrated) != tt.config {
+					t.Errorf("Expected current config to be unchanged, got %s", migrated)
+				}
+				return
+			}
+
+			var document map[string]any
+			if err := json.Unmarshal(migrated, &document); err != nil {
+				t.Fatalf("Expected valid JSON, got %s", migrated)
+			}
+			if document["schemaVersion"] != float64(CurrentSchemaVersion) {
+				t.Errorf("Expected schema version %d, got %v", CurrentSchemaVersion, document["schemaVersion"])
+			}
+			if config, ok := document["config"].(map[string]any); ok {
+				if _, exists := config["flags"]; exists {
+					t.Errorf("Expected raw CLI flags to be removed, got %s", migrated)
+				}
+			}
+			if strings.Contains(tt.config, "9007199254740993") && !strings.Contains(string(migrated), "9007199254740993") {
+				t.Errorf("Expected large numbers to be kept exactly, got %s", migrated)
+			}
+		})
+	}
+}
+
+func TestRegisterConfigMigration_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a second migration from the same version")
+		}
+	}()
+	RegisterConfigMigration(ConfigMigration{From: 0, Migrate: func(ConfigDocument) error { return nil }})
+}
+
+func TestNewMakeMCPApp_SchemaVersion(t *testing.T) {
+	app := NewMakeMCPApp("api", "1.0.0", &mockAppParams{})
+	if app.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", CurrentSchemaVersion, app.SchemaVersion)
+	}
+}
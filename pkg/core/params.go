@@ -14,7 +14,21 @@
 
 package core
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"os"
+)
+
+// EnvironmentVariable selects the environment of a loaded config file if none is given with --env.
+const EnvironmentVariable = "MAKEMCP_ENV"
+
+// SelectedEnvironment returns the environment given as flag, falling back to EnvironmentVariable.
+func SelectedEnvironment(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return os.Getenv(EnvironmentVariable)
+}
 
 // AppParams defines the interface for source-specific parameters.
 // Each source type implements this interface with their own typed parameters
@@ -32,14 +46,25 @@ type AppParams interface {
 	GetSourceType() string
 }
 
+// WatchableParams is implemented by parameters of sources reading local files or remote URLs.
+// In watch mode, the app is reloaded whenever one of these locations changes.
+type WatchableParams interface {
+	// GetWatchLocations returns the file paths and URLs the app is created from
+	GetWatchLocations() []string
+}
+
 // BaseAppParams holds parameters that are common across all source types.
 type BaseAppParams struct {
-	Transport  TransportType `json:"transport"`  // stdio or http
-	ConfigOnly bool          `json:"configOnly"` // if true, only creates config file
-	Port       string        `json:"port"`       // only valid with transport=http
-	DevMode    bool          `json:"devMode"`    // true if running in development mode
-	SourceType string        `json:"sourceType"` // type of source (openapi, cli, etc.)
-	File       string        `json:"file"`       // filename (without extension) for config file
+	Transport  TransportType `json:"transport"`        // stdio or http
+	ConfigOnly bool          `json:"configOnly"`       // if true, only creates config file
+	Port       string        `json:"port"`             // only valid with transport=http
+	DevMode    bool          `json:"devMode"`          // true if running in development mode
+	SourceType string        `json:"sourceType"`       // type of source (openapi, cli, etc.)
+	File       string        `json:"file"`             // filename for config file, the extension is added if it has none
+	Format     string        `json:"format,omitempty"` // format of the config file, json or yaml
+	Discovery  bool          `json:"discovery"`        // if true, tools are found and called through discovery meta-tools
+	Profile    string        `json:"profile"`          // tool profile enabled at startup, all tools if empty
+	Watch      bool          `json:"watch"`            // if true, tools are reloaded when the source files change
 }
 
 // NewBaseParams creates a new SharedParams with default values.
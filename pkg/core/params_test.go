@@ -265,3 +265,18 @@ func TestCLIParamsInput_JSONRoundTrip(t *testing.T) {
 		t.Errorf("CliFlags length mismatch: got %d, want %d", len(unmarshaled.CliFlags), len(original.CliFlags))
 	}
 }
+
+func TestSelectedEnvironment(t *testing.T) {
+	t.Setenv(EnvironmentVariable, "")
+	if env := SelectedEnvironment(""); env != "" {
+		t.Errorf("Expected no environment, got %s", env)
+	}
+
+	t.Setenv(EnvironmentVariable, "staging")
+	if env := SelectedEnvironment(""); env != "staging" {
+		t.Errorf("Expected environment of %s, got %s", EnvironmentVariable, env)
+	}
+	if env := SelectedEnvironment("prod"); env != "prod" {
+		t.Errorf("Expected flag to take precedence, got %s", env)
+	}
+}
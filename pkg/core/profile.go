@@ -0,0 +1,83 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AllToolsProfile is the reserved profile name enabling all tools of the app.
+const AllToolsProfile = "all"
+
+// ToolProfile is a named subset of the app's tools, selected by tag or tool name.
+// It is defined in the "profiles" section of the configuration file.
+type ToolProfile struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`  // Tools with any of these tags, compared case-insensitively
+	Tools       []string `json:"tools,omitempty"` // Tools with these names
+}
+
+// Validate checks that the profile has a name and selects tools.
+func (p ToolProfile) Validate() error {
+	if p.Name == "" {
+		return errors.New("profile name is required")
+	}
+	if p.Name == AllToolsProfile {
+		return fmt.Errorf("profile name %s is reserved", AllToolsProfile)
+	}
+	if len(p.Tags) == 0 && len(p.Tools) == 0 {
+		return fmt.Errorf("profile %s selects no tags or tools", p.Name)
+	}
+	return nil
+}
+
+// Includes returns true if the profile selects tool.
+func (p ToolProfile) Includes(tool MakeMCPTool) bool {
+	if slices.Contains(p.Tools, tool.GetName()) {
+		return true
+	}
+	if tagged, ok := tool.(TaggedTool); ok {
+		for _, tag := range tagged.GetTags() {
+			if slices.ContainsFunc(p.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SelectTools returns the tools of the named profile, or all tools for AllToolsProfile and an empty name.
+func (a *MakeMCPApp) SelectTools(profile string) ([]MakeMCPTool, error) {
+	if profile == "" || profile == AllToolsProfile {
+		return a.Tools, nil
+	}
+	for _, p := range a.Profiles {
+		if p.Name != profile {
+			continue
+		}
+		var tools []MakeMCPTool
+		for _, tool := range a.Tools {
+			if p.Includes(tool) {
+				tools = append(tools, tool)
+			}
+		}
+		return tools, nil
+	}
+	return nil, fmt.Errorf("unknown profile %s", profile)
+}
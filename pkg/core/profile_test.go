@@ -0,0 +1,101 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mockTaggedTool is a mockTool with tags.
+type mockTaggedTool struct {
+	mockTool
+	tags []string
+}
+
+func (m *mockTaggedTool) GetTags() []string {
+	return m.tags
+}
+
+func TestToolProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ToolProfile
+		wantErr string
+	}{
+		{name: "valid", profile: ToolProfile{Name: "read", Tags: []string{"pets"}}},
+		{name: "missing name", profile: ToolProfile{Tools: []string{"a"}}, wantErr: "name is required"},
+		{name: "reserved name", profile: ToolProfile{Name: AllToolsProfile, Tools: []string{"a"}}, wantErr: "is reserved"},
+		{name: "empty selection", profile: ToolProfile{Name: "read"}, wantErr: "selects no tags or tools"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMakeMCPApp_SelectTools(t *testing.T) {
+	app := &MakeMCPApp{
+		Tools: []MakeMCPTool{
+			&mockTaggedTool{mockTool: mockTool{name: "listpets"}, tags: []string{"Pets"}},
+			&mockTaggedTool{mockTool: mockTool{name: "getstore"}, tags: []string{"stores"}},
+			&mockTool{name: "health"},
+		},
+		Profiles: []ToolProfile{
+			{Name: "pets", Tags: []string{"pets"}},
+			{Name: "ops", Tags: []string{"admin"}, Tools: []string{"health"}},
+		},
+	}
+
+	tests := []struct {
+		profile string
+		want    []string
+		wantErr bool
+	}{
+		{profile: "", want: []string{"listpets", "getstore", "health"}},
+		{profile: AllToolsProfile, want: []string{"listpets", "getstore", "health"}},
+		{profile: "pets", want: []string{"listpets"}},
+		{profile: "ops", want: []string{"health"}},
+		{profile: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			tools, err := app.SelectTools(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			var names []string
+			for _, tool := range tools {
+				names = append(names, tool.GetName())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+}
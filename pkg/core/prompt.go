@@ -0,0 +1,72 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// promptPlaceholderPattern matches argument placeholders in prompt messages, e.g. "{{user_id}}".
+var promptPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_\-]+)\s*\}\}`)
+
+// McpPrompt describes a prompt template MCP clients can offer to their users.
+// Prompts are plain data, so they are stored in the configuration file alongside the tools.
+type McpPrompt struct {
+	// The name of the prompt.
+	Name string `json:"name"`
+	// A human-readable description of the prompt.
+	Description string `json:"description,omitempty"`
+	// Name of the tool the prompt calls, its arguments are completed like the tool's parameters.
+	Tool string `json:"tool,omitempty"`
+	// Arguments that can be used to customize the prompt.
+	Arguments []McpPromptArgument `json:"arguments,omitempty"`
+	// Messages of the prompt, their content may contain {{argument}} placeholders.
+	Messages []McpPromptMessage `json:"messages"`
+}
+
+// McpPromptArgument describes an argument a prompt accepts.
+type McpPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// McpPromptMessage is a single text message of a prompt.
+type McpPromptMessage struct {
+	Role    string `json:"role"` // "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// Render returns the messages of the prompt with all placeholders replaced by the given arguments.
+// Placeholders of missing optional arguments are replaced by empty strings.
+func (p McpPrompt) Render(arguments map[string]string) ([]McpPromptMessage, error) {
+	for _, argument := range p.Arguments {
+		if argument.Required && arguments[argument.Name] == "" {
+			return nil, fmt.Errorf("prompt %s requires argument %s", p.Name, argument.Name)
+		}
+	}
+
+	messages := make([]McpPromptMessage, len(p.Messages))
+	for i, message := range p.Messages {
+		messages[i] = McpPromptMessage{
+			Role: message.Role,
+			Content: promptPlaceholderPattern.ReplaceAllStringFunc(message.Content, func(match string) string {
+				return arguments[promptPlaceholderPattern.FindStringSubmatch(match)[1]]
+			}),
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMcpPrompt_Render(t *testing.T) {
+	prompt := McpPrompt{
+		Name: "example",
+		Arguments: []McpPromptArgument{
+			{Name: "path__id", Required: true},
+			{Name: "note"},
+		},
+		Messages: []McpPromptMessage{{Role: "user", Content: `Get user {{path__id}}{{ note }} {"x": 1}`}},
+	}
+
+	tests := []struct {
+		name      string
+		arguments map[string]string
+		want      string
+		wantErr   string
+	}{
+		{"all arguments", map[string]string{"path__id": "7", "note": "!"}, `Get user 7! {"x": 1}`, ""},
+		{"optional argument missing", map[string]string{"path__id": "7"}, `Get user 7 {"x": 1}`, ""},
+		{"required argument missing", map[string]string{"note": "!"}, "", "requires argument path__id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := prompt.Render(tt.arguments)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(messages) != 1 || messages[0].Content != tt.want || messages[0].Role != "user" {
+				t.Errorf("Expected message %q, got %+v", tt.want, messages)
+			}
+		})
+	}
+}
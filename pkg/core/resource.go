@@ -0,0 +1,40 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+// McpResource describes data clients can read as context without a tool call.
+type McpResource struct {
+	// The URI of the resource, or an RFC 6570 URI template if IsTemplate is set.
+	URI string `json:"uri"`
+	// A human-readable name of the resource.
+	Name string `json:"name"`
+	// A description of the resource.
+	Description string `json:"description,omitempty"`
+	// The MIME type of the resource content, if known.
+	MIMEType string `json:"mimeType,omitempty"`
+	// If true, URI is a template whose variables are passed to the resource handler as parameters.
+	IsTemplate bool `json:"isTemplate,omitempty"`
+}
+
+// ResourceProvider is implemented by tools that can also be read as MCP resources.
+// Transports check for this interface when registering tools.
+type ResourceProvider interface {
+	// ToMcpResource returns the resource the tool is exposed as, ok is false if it is not exposed.
+	ToMcpResource() (resource McpResource, ok bool)
+
+	// GetResourceHandler returns the handler reading the resource.
+	// Its parameters are the URI template variables, its content is the resource content.
+	GetResourceHandler() MakeMcpToolHandler
+}
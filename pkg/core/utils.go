@@ -9,11 +9,15 @@ import (
 // configuration with both tools and source parameters of specific concrete types, then converts them to interfaces.
 func UnmarshalConfigWithTypedParams[T MakeMCPTool, P AppParams](data []byte) (*MakeMCPApp, error) {
 	var configData struct {
-		Name       string `json:"name"`
-		Version    string `json:"version"`
-		SourceType string `json:"sourceType"`
-		Tools      []T    `json:"tools"`
-		AppParams  P      `json:"config"`
+		SchemaVersion  int             `json:"schemaVersion"`
+		Name           string          `json:"name"`
+		Version        string          `json:"version"`
+		SourceType     string          `json:"sourceType"`
+		Tools          []T             `json:"tools"`
+		CompositeTools []CompositeTool `json:"compositeTools"`
+		Prompts        []McpPrompt     `json:"prompts"`
+		Profiles       []ToolProfile   `json:"profiles"`
+		AppParams      P               `json:"config"`
 	}
 
 	if err := json.Unmarshal(data, &configData); err != nil {
@@ -27,10 +31,14 @@ func UnmarshalConfigWithTypedParams[T MakeMCPTool, P AppParams](data []byte) (*M
 	}
 
 	return &MakeMCPApp{
-		Name:       configData.Name,
-		Version:    configData.Version,
-		SourceType: configData.SourceType,
-		Tools:      tools,
-		AppParams:  configData.AppParams,
+		SchemaVersion:  configData.SchemaVersion,
+		Name:           configData.Name,
+		Version:        configData.Version,
+		SourceType:     configData.SourceType,
+		Tools:          tools,
+		CompositeTools: configData.CompositeTools,
+		Prompts:        configData.Prompts,
+		Profiles:       configData.Profiles,
+		AppParams:      configData.AppParams,
 	}, nil
 }
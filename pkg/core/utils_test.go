@@ -17,6 +17,7 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -387,3 +388,59 @@ func TestUnmarshalConfigWithTypedParams_TypeConversion(t *testing.T) {
 		t.Errorf("CustomField = %v, want 'type-value'", typedParams.CustomField)
 	}
 }
+
+func TestUnmarshalConfigWithTypedParams_CompositeTools(t *testing.T) {
+	jsonData := `{
+		"name": "CompositeTest",
+		"version": "1.0.0",
+		"sourceType": "test",
+		"tools": [{"name": "get_user"}],
+		"compositeTools": [
+			{
+				"name": "rename_user",
+				"steps": [
+					{"tool": "get_user", "arguments": {"path__id": "{{inputs.id}}"}},
+					{"id": "update", "tool": "update_user", "arguments": {"path__id": "{{steps.get_user.body.id}}"}}
+				]
+			}
+		],
+		"config": {"transport": "stdio", "sourceType": "test"}
+	}`
+
+	app, err := UnmarshalConfigWithTypedParams[*testTool, *testAppParams]([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("UnmarshalConfigWithTypedParams() error = %v", err)
+	}
+
+	if len(app.CompositeTools) != 1 {
+		t.Fatalf("Expected 1 composite tool, got %d", len(app.CompositeTools))
+	}
+	steps := app.CompositeTools[0].Steps
+	if len(steps) != 2 || steps[0].StepID() != "get_user" || steps[1].StepID() != "update" {
+		t.Errorf("Unexpected composite tool steps: %+v", steps)
+	}
+	if steps[1].Arguments["path__id"] != "{{steps.get_user.body.id}}" {
+		t.Errorf("Expected templates to be kept as is, got %v", steps[1].Arguments)
+	}
+}
+
+func TestUnmarshalConfigWithTypedParams_Profiles(t *testing.T) {
+	jsonData := `{
+		"name": "ProfilesTest",
+		"version": "1.0.0",
+		"sourceType": "test",
+		"tools": [{"name": "get_user"}],
+		"profiles": [{"name": "read", "description": "Read-only tools", "tags": ["users"], "tools": ["get_user"]}],
+		"config": {"transport": "stdio", "sourceType": "test"}
+	}`
+
+	app, err := UnmarshalConfigWithTypedParams[*testTool, *testAppParams]([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("UnmarshalConfigWithTypedParams() error = %v", err)
+	}
+
+	want := []ToolProfile{{Name: "read", Description: "Read-only tools", Tags: []string{"users"}, Tools: []string{"get_user"}}}
+	if !reflect.DeepEqual(app.Profiles, want) {
+		t.Errorf("Expected profiles %+v, got %+v", want, app.Profiles)
+	}
+}
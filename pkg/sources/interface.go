@@ -36,5 +36,23 @@ type MakeMCPSource interface {
 	// AttachToolHandlers adds tool handler functions to an existing MakeMCPApp (Step 2: ready to serve)
 	AttachToolHandlers(app *core.MakeMCPApp) error
 
-	UnmarshalConfig(data []byte) (*core.MakeMCPApp, error)
+	// UnmarshalConfig reconstructs a MakeMCPApp from a config file, merging the named environment
+	// of the config into its params if environment is not empty
+	UnmarshalConfig(data []byte, environment string) (*core.MakeMCPApp, error)
+}
+
+// SpecParser is implemented by sources that can create an app directly from a spec,
+// e.g. to validate a spec without generating a config file first.
+type SpecParser interface {
+	ParseSpec(location string) (*core.MakeMCPApp, error)
+}
+
+// OverridableSource is implemented by sources whose runtime parameters can be overridden
+// when loading a config file, so one config file can be reused across environments.
+type OverridableSource interface {
+	// GetOverrideFlags returns the CLI flags of the parameters that can be overridden
+	GetOverrideFlags() []cli.Flag
+
+	// ApplyOverrides applies the given flag values, keyed by flag name, to params loaded from a config file
+	ApplyOverrides(params core.AppParams, flags map[string]any) error
 }
@@ -0,0 +1,427 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// arazzoDocument is the subset of an Arazzo 1.0 document used to build composite tools.
+type arazzoDocument struct {
+	Arazzo string `yaml:"arazzo"`
+	Info   struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	SourceDescriptions []struct {
+		Name string `yaml:"name"`
+		URL  string `yaml:"url"`
+		Type string `yaml:"type"`
+	} `yaml:"sourceDescriptions"`
+	Workflows []arazzoWorkflow `yaml:"workflows"`
+}
+
+// arazzoWorkflow is a workflow of an Arazzo document.
+type arazzoWorkflow struct {
+	WorkflowID  string            `yaml:"workflowId"`
+	Summary     string            `yaml:"summary"`
+	Description string            `yaml:"description"`
+	Inputs      map[string]any    `yaml:"inputs"` // JSON schema of the workflow inputs
+	Steps       []arazzoStep      `yaml:"steps"`
+	Outputs     map[string]string `yaml:"outputs"`
+}
+
+// arazzoStep is a step of an Arazzo workflow.
+type arazzoStep struct {
+	StepID        string `yaml:"stepId"`
+	Description   string `yaml:"description"`
+	OperationID   string `yaml:"operationId"`
+	OperationPath string `yaml:"operationPath"`
+	WorkflowID    string `yaml:"workflowId"`
+	Parameters    []struct {
+		Name  string `yaml:"name"`
+		In    string `yaml:"in"`
+		Value any    `yaml:"value"`
+	} `yaml:"parameters"`
+	RequestBody *struct {
+		ContentType string `yaml:"contentType"`
+		Payload     any    `yaml:"payload"`
+	} `yaml:"requestBody"`
+	SuccessCriteria []struct {
+		Context   string `yaml:"context"`
+		Condition string `yaml:"condition"`
+		Type      any    `yaml:"type"` // Either a type name or a criterion expression type object
+	} `yaml:"successCriteria"`
+	Outputs map[string]string `yaml:"outputs"`
+}
+
+// CreateWorkflowTools reads an Arazzo document and converts each workflow into a composite tool.
+// Steps reference operations of the given tools by operationId or operationPath.
+func (a *LibopenAPIAdapter) CreateWorkflowTools(arazzoLocation string, tools []OpenAPIMcpTool) ([]OpenAPIMcpTool, error) {
+	log.Println("Loading Arazzo workflows from:", arazzoLocation)
+	data, err := a.loadSpecBytes(arazzoLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Arazzo document: %w", err)
+	}
+
+	var doc arazzoDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Arazzo document: %w", err)
+	}
+	if !strings.HasPrefix(doc.Arazzo, "1.") {
+		return nil, fmt.Errorf("unsupported Arazzo version %q, expected 1.x", doc.Arazzo)
+	}
+	if len(doc.SourceDescriptions) > 1 {
+		log.Printf("Warning: Arazzo document has %d source descriptions, all steps are resolved against the loaded OpenAPI spec", len(doc.SourceDescriptions))
+	}
+
+	toolNames := newToolNameSet()
+	for _, tool := range tools {
+		toolNames.claim(tool.Name, tool.OpenAPIHandlerInput.Method, tool.OpenAPIHandlerInput.Path)
+	}
+
+	var workflowTools []OpenAPIMcpTool
+	for _, workflow := range doc.Workflows {
+		tool, err := createWorkflowTool(workflow, tools)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %s: %w", workflow.WorkflowID, err)
+		}
+		tool.Name = toolNames.claim(tool.Name, "workflow", workflow.WorkflowID)
+		tool.Annotations.Title = tool.Name
+		workflowTools = append(workflowTools, tool)
+	}
+	toolNames.warnCollisions()
+	return workflowTools, nil
+}
+
+// createWorkflowTool converts an Arazzo workflow into a composite tool.
+func createWorkflowTool(workflow arazzoWorkflow, tools []OpenAPIMcpTool) (OpenAPIMcpTool, error) {
+	if workflow.WorkflowID == "" {
+		return OpenAPIMcpTool{}, errors.New("workflowId is required")
+	}
+	if len(workflow.Steps) == 0 {
+		return OpenAPIMcpTool{}, errors.New("workflow has no steps")
+	}
+
+	definition := &Workflow{Outputs: workflow.Outputs}
+	var stepTools []*OpenAPIMcpTool
+	for _, arazzoStep := range workflow.Steps {
+		stepTool, err := findStepTool(arazzoStep, tools)
+		if err != nil {
+			return OpenAPIMcpTool{}, fmt.Errorf("step %s: %w", arazzoStep.StepID, err)
+		}
+		step, err := convertArazzoStep(arazzoStep, stepTool)
+		if err != nil {
+			return OpenAPIMcpTool{}, fmt.Errorf("step %s: %w", arazzoStep.StepID, err)
+		}
+		definition.Steps = append(definition.Steps, step)
+		stepTools = append(stepTools, stepTool)
+	}
+
+	description := workflow.Description
+	if description == "" {
+		description = workflow.Summary
+	}
+	if description == "" {
+		description = fmt.Sprintf("Workflow %s", workflow.WorkflowID)
+	}
+	var steps strings.Builder
+	for i, step := range definition.Steps {
+		fmt.Fprintf(&steps, "\n%d. %s (%s)", i+1, step.StepID, step.Tool)
+		if step.Description != "" {
+			fmt.Fprintf(&steps, ": %s", step.Description)
+		}
+	}
+	description += "\n\nExecutes these steps in order:" + steps.String()
+
+	input := NewOpenAPIHandlerInput("", "")
+	input.Workflow = definition
+	return OpenAPIMcpTool{
+		McpTool: core.McpTool{
+			Name:        sanitizeToolName(workflow.WorkflowID),
+			Description: description,
+			InputSchema: getWorkflowInputSchema(workflow.Inputs),
+			Annotations: getWorkflowAnnotations(stepTools),
+		},
+		OpenAPIHandlerInput: &input,
+	}, nil
+}
+
+// findStepTool returns the tool of the operation a step references.
+func findStepTool(step arazzoStep, tools []OpenAPIMcpTool) (*OpenAPIMcpTool, error) {
+	switch {
+	case step.WorkflowID != "":
+		return nil, errors.New("steps referencing other workflows are not supported")
+	case step.OperationID != "":
+		// Operation IDs may be qualified with the source description: $sourceDescriptions.petstore.getPet
+		operationID := step.OperationID
+		if strings.HasPrefix(operationID, "$sourceDescriptions.") {
+			operationID = operationID[strings.LastIndex(operationID, ".")+1:]
+		}
+		for i := range tools {
+			if tools[i].Operation != nil && tools[i].Operation.OperationId == operationID {
+				return &tools[i], nil
+			}
+		}
+		return nil, fmt.Errorf("operation %s not found", step.OperationID)
+	case step.OperationPath != "":
+		// e.g. {$sourceDescriptions.petstore.url}#/paths/~1pets~1{petId}/get
+		_, pointer, _ := strings.Cut(step.OperationPath, "#")
+		segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+		if len(segments) != 3 || segments[0] != "paths" {
+			return nil, fmt.Errorf("invalid operationPath %s", step.OperationPath)
+		}
+		path, method := unescapeJSONPointer(segments[1]), segments[2]
+		for i := range tools {
+			if tools[i].OpenAPIHandlerInput.Path == path && strings.EqualFold(tools[i].OpenAPIHandlerInput.Method, method) {
+				return &tools[i], nil
+			}
+		}
+		return nil, fmt.Errorf("operation %s %s not found", strings.ToUpper(method), path)
+	default:
+		return nil, errors.New("step must reference an operationId or operationPath")
+	}
+}
+
+// convertArazzoStep converts an Arazzo step into a workflow step calling stepTool.
+func convertArazzoStep(arazzoStep arazzoStep, stepTool *OpenAPIMcpTool) (WorkflowStep, error) {
+	step := WorkflowStep{
+		StepID:      arazzoStep.StepID,
+		Description: arazzoStep.Description,
+		Tool:        stepTool.Name,
+		Arguments:   make(map[string]any),
+		Outputs:     arazzoStep.Outputs,
+	}
+	if step.StepID == "" {
+		return step, errors.New("stepId is required")
+	}
+
+	for _, param := range arazzoStep.Parameters {
+		in := param.In
+		if in == "" {
+			// The location may be omitted if the parameter name is unambiguous
+			for _, operationParam := range stepTool.Operation.Parameters {
+				if operationParam != nil && operationParam.Name == param.Name {
+					in = operationParam.In
+				}
+			}
+		}
+		if !ParameterLocation(in).IsValid() {
+			return step, fmt.Errorf("parameter %s has no valid location", param.Name)
+		}
+		step.Arguments[fmt.Sprintf("%s__%s", in, param.Name)] = param.Value
+	}
+
+	if arazzoStep.RequestBody != nil && arazzoStep.RequestBody.Payload != nil {
+		if payload, isObject := arazzoStep.RequestBody.Payload.(map[string]any); isObject {
+			for name, value := range payload {
+				step.Arguments[fmt.Sprintf("%s__%s", ParameterLocationBody, name)] = value
+			}
+		} else {
+			// Raw payloads are sent as the single body parameter
+			step.Arguments[fmt.Sprintf("%s__body", ParameterLocationBody)] = arazzoStep.RequestBody.Payload
+		}
+	}
+
+	for _, criterion := range arazzoStep.SuccessCriteria {
+		converted := SuccessCriterion{Context: criterion.Context, Condition: criterion.Condition}
+		switch criterionType := criterion.Type.(type) {
+		case string:
+			converted.Type = criterionType
+		case map[string]any:
+			converted.Type, _ = criterionType["type"].(string)
+		}
+		step.SuccessCriteria = append(step.SuccessCriteria, converted)
+	}
+	return step, nil
+}
+
+// getWorkflowInputSchema converts the JSON schema of workflow inputs into a tool input schema.
+func getWorkflowInputSchema(inputs map[string]any) core.McpToolInputSchema {
+	schema := core.McpToolInputSchema{Type: "object", Properties: make(map[string]any)}
+	if properties, ok := inputs["properties"].(map[string]any); ok {
+		schema.Properties = properties
+	}
+	if required, ok := inputs["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+	return schema
+}
+
+// getWorkflowAnnotations combines the annotations of all step tools:
+// a workflow is read-only and idempotent only if all steps are, and destructive if any step is.
+func getWorkflowAnnotations(stepTools []*OpenAPIMcpTool) core.McpToolAnnotation {
+	readOnly, idempotent, destructive := true, true, false
+	for _, tool := range stepTools {
+		hints := tool.Annotations
+		readOnly = readOnly && hints.ReadOnlyHint != nil && *hints.ReadOnlyHint
+		idempotent = idempotent && hints.IdempotentHint != nil && *hints.IdempotentHint
+		destructive = destructive || (hints.DestructiveHint != nil && *hints.DestructiveHint)
+	}
+	return core.McpToolAnnotation{
+		ReadOnlyHint:    boolPtr(readOnly),
+		IdempotentHint:  boolPtr(idempotent),
+		DestructiveHint: boolPtr(destructive),
+	}
+}
+
+// embeddedExpressionPattern matches runtime expressions embedded in strings, e.g. "Bearer {$inputs.token}".
+var embeddedExpressionPattern = regexp.MustCompile(`\{(\$[^{}]+)\}`)
+
+// resolveValue replaces runtime expressions and composite tool templates in value, recursing
+// into objects and arrays. A string consisting of a single expression or template is replaced
+// by the typed value; embedded ones are interpolated as text. Object entries resolving to nil are dropped.
+func resolveValue(value any, state *workflowState) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "{{") {
+			return resolveTemplates(v, state)
+		}
+		if strings.HasPrefix(v, "$") && !strings.Contains(v, " ") {
+			return evaluateExpression(v, state)
+		}
+		var resolveErr error
+		result := embeddedExpressionPattern.ReplaceAllStringFunc(v, func(match string) string {
+			resolved, err := evaluateExpression(match[1:len(match)-1], state)
+			if err != nil {
+				resolveErr = err
+			}
+			return formatValue(resolved)
+		})
+		return result, resolveErr
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved, err := resolveValue(item, state)
+			if err != nil {
+				return nil, err
+			}
+			if resolved != nil {
+				result[key] = resolved
+			}
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveValue(item, state)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+// evaluateExpression evaluates an Arazzo runtime expression. Supported expressions are
+// $inputs.<name>, $steps.<stepId>.outputs.<name>, $statusCode, $url, $method,
+// $response.header.<name> and $response.body, optionally followed by a JSON pointer (#/items/0/id).
+// Missing inputs and fields evaluate to nil.
+func evaluateExpression(expression string, state *workflowState) (any, error) {
+	expression, pointer, hasPointer := strings.Cut(strings.TrimSpace(expression), "#")
+
+	var value any
+	switch {
+	case expression == "$inputs":
+		value = state.inputs
+	case strings.HasPrefix(expression, "$inputs."):
+		value, _ = lookupField(state.inputs, strings.TrimPrefix(expression, "$inputs."))
+	case strings.HasPrefix(expression, "$steps."):
+		stepID, field, _ := strings.Cut(strings.TrimPrefix(expression, "$steps."), ".")
+		step, ok := state.steps[stepID]
+		if !ok {
+			return nil, fmt.Errorf("%s refers to step %s which has not been executed", expression, stepID)
+		}
+		outputName, found := strings.CutPrefix(field, "outputs.")
+		if !found {
+			return nil, fmt.Errorf("unsupported expression %s, expected $steps.<stepId>.outputs.<name>", expression)
+		}
+		value, _ = lookupField(step.outputs, outputName)
+	case strings.HasPrefix(expression, "$statusCode"), strings.HasPrefix(expression, "$response."),
+		expression == "$url", expression == "$method":
+		if state.current == nil {
+			return nil, fmt.Errorf("%s is only available in step outputs and success criteria", expression)
+		}
+		switch {
+		case expression == "$statusCode":
+			value = state.current.statusCode
+		case expression == "$url":
+			value = state.current.url
+		case expression == "$method":
+			value = state.current.method
+		case expression == "$response.body":
+			value = state.current.body
+		case strings.HasPrefix(expression, "$response.header."):
+			value = state.current.header.Get(strings.TrimPrefix(expression, "$response.header."))
+		default:
+			return nil, fmt.Errorf("unsupported expression %s", expression)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported expression %s", expression)
+	}
+
+	if hasPointer {
+		value, _ = resolveJSONPointer(value, pointer)
+	}
+	return value, nil
+}
+
+// resolveJSONPointer resolves an RFC 6901 JSON pointer like "/items/0/id" in decoded JSON.
+func resolveJSONPointer(data any, pointer string) (any, bool) {
+	if pointer == "" {
+		return data, true
+	}
+	current := data
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescapeJSONPointer(token)
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// unescapeJSONPointer unescapes a JSON pointer token.
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
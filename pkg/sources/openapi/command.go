@@ -36,7 +36,75 @@ var timeoutFlag cli.IntFlag = cli.IntFlag{
 	Name:    "timeout",
 	Aliases: []string{"to"},
 	Value:   30,
-	Usage:   "HTTP timeout in seconds for API requests (default: 30)",
+	Usage:   "HTTP timeout in seconds for API requests, streaming responses may run longer while data keeps arriving (default: 30)",
+}
+
+var maxResponseSizeFlag cli.IntFlag = cli.IntFlag{
+	Name:  "max-response-size",
+	Value: int(DefaultMaxResponseSize),
+	Usage: "Maximum number of bytes read from API responses and remote specs; larger responses are truncated (default: 10485760)",
+}
+
+var maxRetriesFlag cli.IntFlag = cli.IntFlag{
+	Name:  "max-retries",
+	Value: 0,
+	Usage: "Number of retries for transient API failures (429, 502-504, network errors); only idempotent requests or requests with an idempotency key are retried (default: 0)",
+}
+
+var fileUploadDirFlag cli.StringFlag = cli.StringFlag{
+	Name:  "file-upload-dir",
+	Value: "",
+	Usage: "Allow multipart file uploads from local paths ('file:<path>') inside this directory. Local file uploads are disabled if not set.",
+}
+
+var contentTypesFlag cli.StringSliceFlag = cli.StringSliceFlag{
+	Name:  "content-types",
+	Usage: "Request content types in order of preference, used when an operation accepts several (default: JSON, form, multipart, XML, plain text)",
+}
+
+var contentTypeOverrideFlag cli.StringSliceFlag = cli.StringSliceFlag{
+	Name:  "content-type-override",
+	Usage: "Request content type for a single tool, given as tool=content-type (can be repeated)",
+}
+
+var toolNamingFlag cli.StringFlag = cli.StringFlag{
+	Name:  "tool-naming",
+	Value: ToolNamingOperationID,
+	Usage: "Tool naming strategy: operationId, method_path, tag_prefixed or template (default: operationId)",
+}
+
+var toolNameTemplateFlag cli.StringFlag = cli.StringFlag{
+	Name:  "tool-name-template",
+	Value: "",
+	Usage: "Go template for tool names, e.g. '{{.Tag}}_{{.OperationID}}'; fields: OperationID, Method, Path, Tag, Tags, Summary",
+}
+
+var overlayFlag cli.StringSliceFlag = cli.StringSliceFlag{
+	Name:  "overlay",
+	Usage: "OpenAPI Overlay 1.0 document (file path or URL) applied to the spec before tools are generated (can be repeated, applied in order)",
+}
+
+var arazzoFlag cli.StringFlag = cli.StringFlag{
+	Name:  "arazzo",
+	Value: "",
+	Usage: "Arazzo 1.0 workflow document (file path or URL); each workflow becomes a tool executing its steps against the OpenAPI spec",
+}
+
+var resourcesFlag cli.BoolFlag = cli.BoolFlag{
+	Name:  "resources",
+	Value: false,
+	Usage: "Also expose GET operations as MCP resources; operations with path parameters become resource templates, operations with other required parameters are not exposed",
+}
+
+var promptsFlag cli.BoolFlag = cli.BoolFlag{
+	Name:  "prompts",
+	Value: false,
+	Usage: "Generate MCP prompts: one per tag listing its operations, and one per operation request example",
+}
+
+var completionLookupFlag cli.StringSliceFlag = cli.StringSliceFlag{
+	Name:  "completion-lookup",
+	Usage: "GET operation listing the values of a parameter for argument completion, given as param=tool[:path], e.g. projectId=listprojects:items.id (can be repeated)",
 }
 
 // GetCommand returns the CLI command for this source
@@ -48,6 +116,30 @@ func (s *OpenAPISource) GetCommand() *cli.Command {
 			&specsFlag,
 			&baseUrlFlag,
 			&timeoutFlag,
+			&maxResponseSizeFlag,
+			&maxRetriesFlag,
+			&fileUploadDirFlag,
+			&contentTypesFlag,
+			&contentTypeOverrideFlag,
+			&toolNamingFlag,
+			&toolNameTemplateFlag,
+			&overlayFlag,
+			&arazzoFlag,
+			&resourcesFlag,
+			&promptsFlag,
+			&completionLookupFlag,
 		},
 	}
 }
+
+// GetOverrideFlags returns the flags of parameters only used when calling the API,
+// which the load command can override without changing the generated tools.
+func (s *OpenAPISource) GetOverrideFlags() []cli.Flag {
+	return []cli.Flag{
+		&baseUrlFlag,
+		&timeoutFlag,
+		&maxResponseSizeFlag,
+		&maxRetriesFlag,
+		&fileUploadDirFlag,
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// completionCacheTTL is how long the values returned by a completion lookup are reused.
+const completionCacheTTL = 5 * time.Minute
+
+// CompletionLookup designates a GET operation listing the known values of a parameter,
+// e.g. a list projects operation for a project ID parameter.
+type CompletionLookup struct {
+	Tool string `json:"tool"`           // Tool of the GET operation, called without arguments
+	Path string `json:"path,omitempty"` // Dot-separated path to the values in the JSON response, arrays are traversed (e.g. "items.id")
+}
+
+// completer suggests parameter values from schema enums and completion lookups.
+type completer struct {
+	lookups   map[string]CompletionLookup // Parameter name (plain or prefixed) to lookup
+	tools     map[string]*OpenAPIMcpTool
+	apiClient *APIClient
+
+	mu    sync.Mutex
+	cache map[CompletionLookup]cachedCompletion
+}
+
+// cachedCompletion holds the values of a lookup until they expire.
+type cachedCompletion struct {
+	values  []string
+	expires time.Time
+}
+
+// newCompleter creates a completer, checking that all lookups refer to GET operation tools.
+func newCompleter(lookups map[string]CompletionLookup, tools map[string]*OpenAPIMcpTool, apiClient *APIClient) (*completer, error) {
+	for param, lookup := range lookups {
+		tool, ok := tools[lookup.Tool]
+		if !ok {
+			return nil, fmt.Errorf("completion lookup for %s refers to unknown tool %s", param, lookup.Tool)
+		}
+		if tool.OpenAPIHandlerInput == nil || !strings.EqualFold(tool.OpenAPIHandlerInput.Method, http.MethodGet) {
+			return nil, fmt.Errorf("completion lookup for %s must refer to a GET operation, %s is not", param, lookup.Tool)
+		}
+	}
+	return &completer{
+		lookups:   lookups,
+		tools:     tools,
+		apiClient: apiClient,
+		cache:     make(map[CompletionLookup]cachedCompletion),
+	}, nil
+}
+
+// complete returns the values of a prefixed parameter of tool starting with prefix.
+// Enum values declared by the schema take precedence over lookups.
+func (c *completer) complete(ctx context.Context, tool *OpenAPIMcpTool, param, prefix string) ([]string, error) {
+	var values []string
+	if property, ok := tool.InputSchema.Properties[param].(map[string]any); ok {
+		if enum, ok := property["enum"].([]any); ok {
+			for _, value := range enum {
+				values = append(values, formatValue(value))
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		lookup, ok := c.lookups[param]
+		if !ok {
+			_, name, _ := strings.Cut(param, "__")
+			lookup, ok = c.lookups[name]
+		}
+		if ok {
+			looked, err := c.lookup(ctx, lookup)
+			if err != nil {
+				return nil, err
+			}
+			values = looked
+		}
+	}
+	return filterCompletions(values, prefix), nil
+}
+
+// lookup returns the values listed by a lookup operation, cached for completionCacheTTL.
+func (c *completer) lookup(ctx context.Context, lookup CompletionLookup) ([]string, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[lookup]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.values, nil
+	}
+
+	tool := c.tools[lookup.Tool]
+	call, err := c.apiClient.callOperation(ctx, tool, core.NewBasicExecutionContext(tool.Name, map[string]any{}, ""))
+	if err != nil {
+		return nil, fmt.Errorf("completion lookup %s failed: %w", lookup.Tool, err)
+	}
+	if call.response.statusCode >= 400 {
+		return nil, fmt.Errorf("completion lookup %s failed with status %d", lookup.Tool, call.response.statusCode)
+	}
+	var data any
+	if err := json.Unmarshal(call.response.body, &data); err != nil {
+		return nil, fmt.Errorf("completion lookup %s did not return JSON: %w", lookup.Tool, err)
+	}
+	values := extractLookupValues(data, lookup.Path)
+
+	c.mu.Lock()
+	c.cache[lookup] = cachedCompletion{values: values, expires: time.Now().Add(completionCacheTTL)}
+	c.mu.Unlock()
+	return values, nil
+}
+
+// extractLookupValues collects the scalar values at a dot-separated path, traversing arrays.
+func extractLookupValues(data any, path string) []string {
+	if items, ok := data.([]any); ok {
+		var values []string
+		for _, item := range items {
+			values = append(values, extractLookupValues(item, path)...)
+		}
+		return values
+	}
+	if path == "" {
+		switch data.(type) {
+		case nil, map[string]any:
+			return nil
+		}
+		return []string{formatValue(data)}
+	}
+	object, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+	field, rest, _ := strings.Cut(path, ".")
+	return extractLookupValues(object[field], rest)
+}
+
+// filterCompletions returns the distinct values starting with prefix, ignoring case.
+func filterCompletions(values []string, prefix string) []string {
+	var result []string
+	prefix = strings.ToLower(prefix)
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), prefix) && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
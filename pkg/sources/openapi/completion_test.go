@@ -0,0 +1,179 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const completionSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Projects API", "version": "1.0.0"},
+  "paths": {
+    "/projects": {
+      "get": {
+        "operationId": "listProjects",
+        "responses": {"200": {"description": "ok", "content": {"application/json": {}}}}
+      },
+      "post": {
+        "operationId": "createProject",
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/projects/{project-id}": {
+      "get": {
+        "operationId": "getProject",
+        "parameters": [{"name": "project-id", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {}}}}
+      }
+    },
+    "/projects/{project-id}/tasks": {
+      "get": {
+        "operationId": "listTasks",
+        "parameters": [
+          {"name": "project-id", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"name": "status", "in": "query", "schema": {"type": "string", "enum": ["open", "closed", "on-hold"]}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+// newCompletionTools parses completionSpec against server and attaches the handlers with the given lookups.
+func newCompletionTools(t *testing.T, serverURL string, lookups map[string]CompletionLookup) map[string]*OpenAPIMcpTool {
+	t.Helper()
+	params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.DevMode = true
+	params.Specs = writeTestFile(t, t.TempDir(), "projects.json", completionSpec)
+	params.BaseURL = serverURL
+	params.Resources = true
+	params.CompletionLookups = lookups
+
+	source := NewOpenAPISource()
+	app, err := source.Parse(params)
+	if err != nil {
+		t.Fatalf("Expected no error from Parse but got: %v", err)
+	}
+	if err := source.AttachToolHandlers(app); err != nil {
+		t.Fatalf("Failed to attach handlers: %v", err)
+	}
+	tools := make(map[string]*OpenAPIMcpTool)
+	for _, tool := range app.Tools {
+		tools[tool.GetName()] = tool.(*OpenAPIMcpTool)
+	}
+	return tools
+}
+
+func TestCompleteArgument_Enum(t *testing.T) {
+	tools := newCompletionTools(t, "https://api.example.com", nil)
+
+	values, err := tools["listtasks"].CompleteArgument(context.Background(), "query__status", "o")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if want := []string{"open", "on-hold"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+
+	property := tools["listtasks"].InputSchema.Properties["query__status"].(map[string]any)
+	if enum, ok := property["enum"].([]any); !ok || len(enum) != 3 {
+		t.Errorf("Expected enum in the input schema, got %v", property)
+	}
+}
+
+func TestCompleteArgument_Lookup(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": [{"id": "alpha"}, {"id": "beta"}, {"id": "Alpine"}]}`))
+	}))
+	defer server.Close()
+
+	tools := newCompletionTools(t, server.URL, map[string]CompletionLookup{
+		"project-id": {Tool: "listprojects", Path: "items.id"},
+	})
+
+	values, err := tools["listtasks"].CompleteArgument(context.Background(), "path__project-id", "al")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if want := []string{"alpha", "Alpine"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+
+	// Resource template variables complete like the parameter they stand for
+	values, err = tools["getproject"].CompleteArgument(context.Background(), "project_id", "b")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if want := []string{"beta"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected lookup results to be cached, got %d requests", got)
+	}
+}
+
+func TestNewCompleter_InvalidLookups(t *testing.T) {
+	tools := createToolsFromSpec(t, completionSpec)
+
+	tests := []struct {
+		name   string
+		lookup CompletionLookup
+	}{
+		{"unknown tool", CompletionLookup{Tool: "missing"}},
+		{"not a GET operation", CompletionLookup{Tool: "createproject"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newCompleter(map[string]CompletionLookup{"project-id": tt.lookup}, tools, nil); err == nil {
+				t.Error("Expected error for invalid lookup")
+			}
+		})
+	}
+}
+
+func TestExtractLookupValues(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		path string
+		want []string
+	}{
+		{"top-level array", []any{"a", "b"}, "", []string{"a", "b"}},
+		{"array of objects", []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}}, "id", []string{"1", "2"}},
+		{"nested path", map[string]any{"data": map[string]any{"items": []any{map[string]any{"name": "x"}}}}, "data.items.name", []string{"x"}},
+		{"missing field", map[string]any{"items": []any{}}, "data", nil},
+		{"objects are skipped", []any{map[string]any{"id": "a"}}, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractLookupValues(tt.data, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
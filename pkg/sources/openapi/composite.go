@@ -0,0 +1,160 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// templatePattern matches composite tool templates like "{{steps.get_user.body.id}}".
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// createCompositeTools converts the compositeTools section of the configuration into
+// workflow tools. Steps may only call operation tools, not other composite tools.
+func createCompositeTools(definitions []core.CompositeTool, tools map[string]*OpenAPIMcpTool) ([]*OpenAPIMcpTool, error) {
+	compositeTools := make([]*OpenAPIMcpTool, 0, len(definitions))
+	for _, definition := range definitions {
+		tool, err := createCompositeTool(definition, tools)
+		if err != nil {
+			return nil, fmt.Errorf("invalid composite tool %s: %w", definition.Name, err)
+		}
+		compositeTools = append(compositeTools, tool)
+	}
+	return compositeTools, nil
+}
+
+// createCompositeTool converts a single composite tool definition into a workflow tool.
+func createCompositeTool(definition core.CompositeTool, tools map[string]*OpenAPIMcpTool) (*OpenAPIMcpTool, error) {
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+	if _, exists := tools[definition.Name]; exists {
+		return nil, fmt.Errorf("a tool named %s already exists", definition.Name)
+	}
+
+	workflow := &Workflow{Outputs: definition.Outputs}
+	var stepTools []*OpenAPIMcpTool
+	for _, step := range definition.Steps {
+		stepTool, ok := tools[step.Tool]
+		if !ok {
+			return nil, fmt.Errorf("step %s calls unknown tool %s", step.StepID(), step.Tool)
+		}
+		if stepTool.OpenAPIHandlerInput == nil || stepTool.OpenAPIHandlerInput.Workflow != nil {
+			return nil, fmt.Errorf("step %s calls %s, which is not an operation tool", step.StepID(), step.Tool)
+		}
+		workflow.Steps = append(workflow.Steps, WorkflowStep{
+			StepID:    step.StepID(),
+			Tool:      step.Tool,
+			Arguments: step.Arguments,
+		})
+		stepTools = append(stepTools, stepTool)
+	}
+
+	// Without explicit outputs the result contains the response body of every step
+	if len(workflow.Outputs) == 0 {
+		workflow.Outputs = make(map[string]string, len(workflow.Steps))
+		for _, step := range workflow.Steps {
+			workflow.Outputs[step.StepID] = fmt.Sprintf("{{steps.%s.body}}", step.StepID)
+		}
+	}
+
+	description := definition.Description
+	if description == "" {
+		description = fmt.Sprintf("Composite tool %s", definition.Name)
+	}
+	var steps strings.Builder
+	for i, step := range workflow.Steps {
+		fmt.Fprintf(&steps, "\n%d. %s (%s)", i+1, step.StepID, step.Tool)
+	}
+	description += "\n\nExecutes these steps in order:" + steps.String()
+
+	inputSchema := definition.InputSchema
+	if inputSchema.Type == "" {
+		inputSchema.Type = "object"
+	}
+
+	input := NewOpenAPIHandlerInput("", "")
+	input.Workflow = workflow
+	return &OpenAPIMcpTool{
+		McpTool: core.McpTool{
+			Name:        definition.Name,
+			Description: description,
+			InputSchema: inputSchema,
+			Annotations: getWorkflowAnnotations(stepTools),
+		},
+		OpenAPIHandlerInput: &input,
+	}, nil
+}
+
+// resolveTemplates replaces the templates in value. A value consisting of a single
+// template is replaced by the typed value, embedded templates are interpolated as text.
+func resolveTemplates(value string, state *workflowState) (any, error) {
+	if match := templatePattern.FindStringSubmatchIndex(value); match != nil && match[0] == 0 && match[1] == len(value) {
+		return evaluateTemplate(value[match[2]:match[3]], state)
+	}
+	var resolveErr error
+	result := templatePattern.ReplaceAllStringFunc(value, func(match string) string {
+		resolved, err := evaluateTemplate(templatePattern.FindStringSubmatch(match)[1], state)
+		if err != nil && resolveErr == nil {
+			resolveErr = err
+		}
+		return formatValue(resolved)
+	})
+	return result, resolveErr
+}
+
+// evaluateTemplate evaluates a composite tool template. Supported templates are inputs.<name>,
+// steps.<id>.body.<path>, steps.<id>.status, steps.<id>.headers.<name> and steps.<id>.outputs.<name>,
+// where paths are dot-separated field names or array indexes (items.0.id).
+// Missing inputs and fields evaluate to nil.
+func evaluateTemplate(template string, state *workflowState) (any, error) {
+	root, rest, _ := strings.Cut(template, ".")
+	switch root {
+	case "inputs":
+		if rest == "" {
+			return state.inputs, nil
+		}
+		value, _ := lookupField(state.inputs, rest)
+		return value, nil
+	case "steps":
+		stepID, field, _ := strings.Cut(rest, ".")
+		step, ok := state.steps[stepID]
+		if !ok {
+			return nil, fmt.Errorf("{{%s}} refers to step %s which has not been executed", template, stepID)
+		}
+		name, path, _ := strings.Cut(field, ".")
+		switch name {
+		case "body":
+			if path == "" {
+				return step.body, nil
+			}
+			value, _ := lookupField(step.body, path)
+			return value, nil
+		case "status":
+			return step.statusCode, nil
+		case "headers":
+			return step.header.Get(path), nil
+		case "outputs":
+			value, _ := lookupField(step.outputs, path)
+			return value, nil
+		}
+		return nil, fmt.Errorf("unsupported template {{%s}}, expected steps.<id>.body, status, headers or outputs", template)
+	}
+	return nil, fmt.Errorf("unsupported template {{%s}}, expected inputs.<name> or steps.<id>.<field>", template)
+}
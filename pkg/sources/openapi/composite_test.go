@@ -0,0 +1,224 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// orderCompositeTool adds an item to a new order and confirms it.
+var orderCompositeTool = core.CompositeTool{
+	Name:        "order_item",
+	Description: "Order a single item.",
+	InputSchema: core.McpToolInputSchema{
+		Type:       "object",
+		Properties: map[string]any{"sku": map[string]any{"type": "string"}},
+		Required:   []string{"sku"},
+	},
+	Steps: []core.CompositeStep{
+		{ID: "create", Tool: "createorder", Arguments: map[string]any{"body__customer": "composite"}},
+		{Tool: "additem", Arguments: map[string]any{
+			"path__orderId": "{{steps.create.body.id}}",
+			"body__sku":     "{{inputs.sku}}",
+		}},
+		{ID: "confirm", Tool: "confirmorder", Arguments: map[string]any{"path__orderId": "{{ steps.create.body.id }}"}},
+	},
+}
+
+// parseCompositeApp parses the orders spec and attaches handlers for the given composite tools.
+func parseCompositeApp(t *testing.T, baseURL string, compositeTools ...core.CompositeTool) (map[string]*OpenAPIMcpTool, error) {
+	t.Helper()
+	params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.DevMode = true
+	params.Specs = writeTestFile(t, t.TempDir(), "orders.json", ordersSpec)
+	params.BaseURL = baseURL
+
+	source := NewOpenAPISource()
+	app, err := source.Parse(params)
+	if err != nil {
+		t.Fatalf("Expected no error from Parse but got: %v", err)
+	}
+	app.CompositeTools = compositeTools
+	if err := source.AttachToolHandlers(app); err != nil {
+		return nil, err
+	}
+	tools := make(map[string]*OpenAPIMcpTool)
+	for _, tool := range app.Tools {
+		tools[tool.GetName()] = tool.(*OpenAPIMcpTool)
+	}
+	return tools, nil
+}
+
+func TestCompositeTool_ExecutesSteps(t *testing.T) {
+	server, requests := newOrdersServer(t, "confirmed")
+	tools, err := parseCompositeApp(t, server.URL, orderCompositeTool)
+	if err != nil {
+		t.Fatalf("Failed to attach handlers: %v", err)
+	}
+
+	compositeTool := tools["order_item"]
+	if compositeTool == nil {
+		t.Fatalf("Expected composite tool order_item, got %v", tools)
+	}
+	if compositeTool.InputSchema.Required[0] != "sku" {
+		t.Errorf("Expected input schema from the definition, got %+v", compositeTool.InputSchema)
+	}
+
+	params := map[string]any{"sku": "A-1"}
+	result, err := compositeTool.GetHandler()(context.Background(), core.NewBasicExecutionContext("order_item", params, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() != nil {
+		t.Fatalf("Expected composite tool to succeed, got: %v", result.GetError())
+	}
+
+	var content struct {
+		Outputs map[string]map[string]any `json:"outputs"`
+		Steps   []map[string]any          `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(result.GetContent()), &content); err != nil {
+		t.Fatalf("Expected JSON result, got %s", result.GetContent())
+	}
+	if content.Outputs["create"]["id"] != float64(42) || content.Outputs["additem"]["items"] != float64(1) ||
+		content.Outputs["confirm"]["state"] != "confirmed" {
+		t.Errorf("Expected the body of every step in the outputs, got: %v", content.Outputs)
+	}
+	if len(content.Steps) != 3 {
+		t.Errorf("Expected 3 executed steps, got %v", content.Steps)
+	}
+
+	expected := []string{
+		`POST /orders {"customer":"composite"}`,
+		`POST /orders/42/items {"sku":"A-1"}`,
+		`POST /orders/42/confirm `,
+	}
+	if strings.Join(*requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected requests:\n%s\nwant:\n%s", strings.Join(*requests, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestCompositeTool_StopsOnFailedStep(t *testing.T) {
+	server, requests := newOrdersServer(t, "confirmed")
+	definition := core.CompositeTool{
+		Name: "confirm_items",
+		Steps: []core.CompositeStep{
+			{ID: "add", Tool: "additem", Arguments: map[string]any{"path__orderId": "{{inputs.orderId}}"}},
+			{ID: "confirm", Tool: "confirmorder", Arguments: map[string]any{"path__orderId": "{{inputs.orderId}}"}},
+		},
+		Outputs: map[string]string{"state": "{{steps.confirm.body.state}}"},
+	}
+	tools, err := parseCompositeApp(t, server.URL, definition)
+	if err != nil {
+		t.Fatalf("Failed to attach handlers: %v", err)
+	}
+
+	params := map[string]any{"orderId": 7}
+	result, err := tools["confirm_items"].GetHandler()(context.Background(), core.NewBasicExecutionContext("confirm_items", params, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() == nil || !strings.Contains(result.GetError().Error(), "workflow step add failed") {
+		t.Fatalf("Expected failure of step add, got: %v", result.GetError())
+	}
+	if len(*requests) != 1 {
+		t.Errorf("Expected execution to stop after the failed step, got requests %v", *requests)
+	}
+}
+
+func TestCreateCompositeTools_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition core.CompositeTool
+		wantErr    string
+	}{
+		{
+			name:       "unknown tool",
+			definition: core.CompositeTool{Name: "c", Steps: []core.CompositeStep{{Tool: "missing"}}},
+			wantErr:    "unknown tool missing",
+		},
+		{
+			name:       "name collision",
+			definition: core.CompositeTool{Name: "createorder", Steps: []core.CompositeStep{{Tool: "additem"}}},
+			wantErr:    "already exists",
+		},
+		{
+			name:       "no steps",
+			definition: core.CompositeTool{Name: "c"},
+			wantErr:    "has no steps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCompositeApp(t, "http://localhost", tt.definition)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveTemplates(t *testing.T) {
+	state := &workflowState{
+		inputs: map[string]any{"name": "jane", "count": 2},
+		steps: map[string]*stepResult{
+			"get_user": {
+				statusCode: 200,
+				body:       map[string]any{"id": float64(7), "tags": []any{"a", "b"}},
+				outputs:    map[string]any{},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    any
+		wantErr bool
+	}{
+		{"typed input", "{{inputs.count}}", 2, false},
+		{"typed body field", "{{steps.get_user.body.id}}", float64(7), false},
+		{"array index", "{{steps.get_user.body.tags.1}}", "b", false},
+		{"status", "{{steps.get_user.status}}", 200, false},
+		{"interpolated", "user {{steps.get_user.body.id}} of {{inputs.name}}", "user 7 of jane", false},
+		{"missing field", "{{steps.get_user.body.missing}}", nil, false},
+		{"unknown step", "{{steps.other.body.id}}", nil, true},
+		{"unknown root", "{{user.id}}", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTemplates(tt.value, state)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %v", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v (%T), got %v (%T)", tt.want, tt.want, got, got)
+			}
+		})
+	}
+}
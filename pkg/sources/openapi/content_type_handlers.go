@@ -22,6 +22,8 @@ import (
 	"log"
 	"mime/multipart"
 	"net/url"
+	"slices"
+	"sort"
 	"strings"
 
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
@@ -52,6 +54,7 @@ func extractSchemaProperties(media *v3.MediaType, prefix string) (map[string]Too
 			Type:        GetSchemaTypeString(propSchemaProxy),
 			Description: propSchema.Description,
 			Location:    "body",
+			Enum:        getSchemaEnum(propSchemaProxy),
 		}
 	}
 
@@ -124,20 +127,38 @@ type ContentTypeHandler interface {
 	BuildRequestBody(bodyParams map[string]any) (io.Reader, error)
 }
 
+// DefaultContentTypePreferences is the order in which request content types are chosen
+// if an operation declares more than one. Wildcards come last as they carry the least information.
+var DefaultContentTypePreferences = []string{
+	"application/json",
+	"application/hal+json",
+	"application/vnd.api+json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+	"application/xml",
+	"text/xml",
+	"text/plain",
+	"text/*",
+	"*/*",
+}
+
 // ContentTypeRegistry manages content type handlers
 type ContentTypeRegistry struct {
-	handlers map[string]ContentTypeHandler
-	fallback ContentTypeHandler
+	handlers    map[string]ContentTypeHandler
+	order       []string // Content types in order of registration
+	preferences []string // Preferred content types, most preferred first
+	fallback    ContentTypeHandler
 }
 
 // NewContentTypeRegistry creates a new registry with default handlers
 func NewContentTypeRegistry() *ContentTypeRegistry {
 	registry := &ContentTypeRegistry{
-		handlers: make(map[string]ContentTypeHandler),
+		handlers:    make(map[string]ContentTypeHandler),
+		preferences: DefaultContentTypePreferences,
 	}
 
 	// Register default handlers
-	// Note: the order determines the order in which content type assignment takes place!
+	// Note: content types without preference are assigned in order of registration
 	registry.RegisterHandler(&JSONContentTypeHandler{})
 	registry.RegisterHandler(&XMLContentTypeHandler{})
 	registry.RegisterHandler(&FormURLEncodedHandler{})
@@ -153,12 +174,32 @@ func NewContentTypeRegistry() *ContentTypeRegistry {
 // RegisterHandler registers a content type handler
 func (r *ContentTypeRegistry) RegisterHandler(handler ContentTypeHandler) {
 	for _, contentType := range handler.GetContentTypes() {
+		if _, exists := r.handlers[contentType]; !exists {
+			r.order = append(r.order, contentType)
+		}
 		r.handlers[contentType] = handler
 	}
 }
 
+// SetPreferences replaces the content type preference order, most preferred first.
+// Registered content types missing from the list are ranked after it in order of registration.
+// An empty list restores DefaultContentTypePreferences.
+func (r *ContentTypeRegistry) SetPreferences(contentTypes []string) {
+	if len(contentTypes) == 0 {
+		r.preferences = DefaultContentTypePreferences
+		return
+	}
+	r.preferences = nil
+	for _, contentType := range contentTypes {
+		if normalized := normalizeMediaType(contentType); normalized != "" {
+			r.preferences = append(r.preferences, normalized)
+		}
+	}
+}
+
 // GetHandler returns the appropriate handler for a content type
 func (r *ContentTypeRegistry) GetHandler(contentType string) ContentTypeHandler {
+	contentType = normalizeMediaType(contentType)
 	if handler, exists := r.handlers[contentType]; exists {
 		return handler
 	}
@@ -176,15 +217,63 @@ func (r *ContentTypeRegistry) GetHandler(contentType string) ContentTypeHandler
 	return r.fallback
 }
 
-// GetAllContentTypes returns all content types of all handlers in this registry in order of registration (FIFO)
+// GetAllContentTypes returns all known content types in order of preference,
+// followed by the remaining registered content types in order of registration (FIFO)
 func (r *ContentTypeRegistry) GetAllContentTypes() []string {
 	var result []string
-	for _, handler := range r.handlers {
-		result = append(result, handler.GetContentTypes()...)
+	seen := make(map[string]bool)
+	for _, contentType := range append(slices.Clone(r.preferences), r.order...) {
+		if !seen[contentType] {
+			seen[contentType] = true
+			result = append(result, contentType)
+		}
 	}
 	return result
 }
 
+// SelectContentType returns the most preferred of the given content types.
+// Content types unknown to the registry rank last, keeping their original order.
+func (r *ContentTypeRegistry) SelectContentType(contentTypes []string) string {
+	if len(contentTypes) == 0 {
+		return ""
+	}
+	for _, preferred := range r.GetAllContentTypes() {
+		for _, contentType := range contentTypes {
+			if normalizeMediaType(contentType) == preferred {
+				return contentType
+			}
+		}
+	}
+	return contentTypes[0]
+}
+
+// SortContentTypes returns the given content types ordered by preference.
+// The sort is stable, so content types unknown to the registry keep their original order.
+func (r *ContentTypeRegistry) SortContentTypes(contentTypes []string) []string {
+	rank := make(map[string]int)
+	for i, contentType := range r.GetAllContentTypes() {
+		rank[contentType] = i
+	}
+	rankOf := func(contentType string) int {
+		if i, ok := rank[normalizeMediaType(contentType)]; ok {
+			return i
+		}
+		return len(rank)
+	}
+
+	result := slices.Clone(contentTypes)
+	slices.SortStableFunc(result, func(a, b string) int {
+		return rankOf(a) - rankOf(b)
+	})
+	return result
+}
+
+// normalizeMediaType lowercases a content type and strips its parameters.
+func normalizeMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
 // JSONContentTypeHandler handles JSON content types
 type JSONContentTypeHandler struct{}
 
@@ -211,7 +300,9 @@ func (h *JSONContentTypeHandler) BuildRequestBody(bodyParams map[string]any) (io
 }
 
 // XMLContentTypeHandler handles XML content types (both structured and raw)
-type XMLContentTypeHandler struct{}
+type XMLContentTypeHandler struct {
+	Schema *XMLNode // XML hints of the request body schema, nil serializes with a generic root element
+}
 
 // GetContentTypes returns the content types supported by this handler.
 func (h *XMLContentTypeHandler) GetContentTypes() []string {
@@ -254,14 +345,12 @@ func (h *XMLContentTypeHandler) BuildRequestBody(bodyParams map[string]any) (io.
 		return nil, fmt.Errorf("XML body parameter must be a string containing valid XML")
 	}
 
-	// TODO: For structured XML, we should convert the object to actual XML
-	// For now, fall back to JSON serialization (same as current behavior)
-	// In the future, we could use xml.Marshal or a dedicated XML library
-	jsonBody, err := json.Marshal(bodyParams)
+	// Structured XML - serialize using the schema's xml hints
+	xmlBody, err := marshalXML(h.Schema, bodyParams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal XML body (using JSON fallback): %w", err)
+		return nil, fmt.Errorf("failed to marshal XML body: %w", err)
 	}
-	return bytes.NewReader(jsonBody), nil
+	return bytes.NewReader(xmlBody), nil
 }
 
 // FormURLEncodedHandler handles application/x-www-form-urlencoded
@@ -351,7 +440,10 @@ func (h *FormURLEncodedHandler) BuildRequestBody(bodyParams map[string]any) (io.
 }
 
 // MultipartFormDataHandler handles multipart/form-data
-type MultipartFormDataHandler struct{}
+type MultipartFormDataHandler struct {
+	FileFields    []string // Field names (without multipart__ prefix) that are file uploads
+	FileUploadDir string   // Directory local files may be read from, local paths are rejected if empty
+}
 
 // GetContentTypes returns the content types supported by this handler.
 func (h *MultipartFormDataHandler) GetContentTypes() []string {
@@ -376,26 +468,45 @@ func (h *MultipartFormDataHandler) ExtractParameters(media *v3.MediaType) (map[s
 	}
 
 	// Post-process to handle file detection for multipart
-	for propPairs := schema.Properties.First(); propPairs != nil; propPairs = propPairs.Next() {
-		propName := propPairs.Key()
-		propSchemaProxy := propPairs.Value()
-		propSchema := propSchemaProxy.Schema()
-
+	for _, propName := range getMultipartFileFields(media) {
 		prefixedName := fmt.Sprintf("multipart__%s", propName)
+		if prop, exists := properties[prefixedName]; exists {
+			prop.Type = "file"
+			properties[prefixedName] = prop
+		}
+	}
+
+	return properties, required, nil
+}
 
-		// Detect file uploads (binary format) and update the type
+// getMultipartFileFields returns the names of all binary (file upload) properties of a multipart schema.
+func getMultipartFileFields(media *v3.MediaType) []string {
+	var fileFields []string
+	if !hasSchemaProps(media) {
+		return fileFields
+	}
+	for propPairs := media.Schema.Schema().Properties.First(); propPairs != nil; propPairs = propPairs.Next() {
+		propSchema := propPairs.Value().Schema()
 		if propSchema != nil && propSchema.Format == "binary" {
-			if prop, exists := properties[prefixedName]; exists {
-				prop.Type = "file"
-				properties[prefixedName] = prop
-			}
+			fileFields = append(fileFields, propPairs.Key())
 		}
 	}
+	return fileFields
+}
 
-	return properties, required, nil
+// multipartBody is a multipart request body together with its boundary-carrying Content-Type.
+type multipartBody struct {
+	*bytes.Buffer
+	contentType string
+}
+
+// ContentType returns the Content-Type header value including the multipart boundary.
+func (b *multipartBody) ContentType() string {
+	return b.contentType
 }
 
 // BuildRequestBody builds a multipart form request body from the provided parameters.
+// File fields accept base64-encoded content or, if FileUploadDir is set, "file:<path>" references.
 func (h *MultipartFormDataHandler) BuildRequestBody(bodyParams map[string]any) (io.Reader, error) {
 	if len(bodyParams) == 0 {
 		return nil, nil
@@ -413,16 +524,43 @@ func (h *MultipartFormDataHandler) BuildRequestBody(bodyParams map[string]any) (
 	var body bytes.Buffer
 	writer := multipart.NewWriter(&body)
 
+	// Write fields in a stable order
+	paramNames := make([]string, 0, len(bodyParams))
+	for paramName := range bodyParams {
+		paramNames = append(paramNames, paramName)
+	}
+	sort.Strings(paramNames)
+
 	hasMultipartParams := false
-	for paramName, value := range bodyParams {
-		if fieldName, found := strings.CutPrefix(paramName, "multipart__"); found {
-			hasMultipartParams = true
+	for _, paramName := range paramNames {
+		fieldName, found := strings.CutPrefix(paramName, "multipart__")
+		if !found {
+			continue
+		}
+		hasMultipartParams = true
+		value := bodyParams[paramName]
 
-			// TODO: Handle file uploads properly
-			// For now, treat everything as form fields
+		if !contains(h.FileFields, fieldName) {
 			if err := writer.WriteField(fieldName, fmt.Sprintf("%v", value)); err != nil {
 				return nil, fmt.Errorf("failed to write multipart field %s: %w", fieldName, err)
 			}
+			continue
+		}
+
+		valueStr, isString := value.(string)
+		if !isString {
+			return nil, fmt.Errorf("multipart file field %s must be a string", fieldName)
+		}
+		filename, content, err := resolveFileUpload(fieldName, valueStr, h.FileUploadDir)
+		if err != nil {
+			return nil, err
+		}
+		part, err := writer.CreateFormFile(fieldName, filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create multipart file %s: %w", fieldName, err)
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, fmt.Errorf("failed to write multipart file %s: %w", fieldName, err)
 		}
 	}
 
@@ -434,10 +572,7 @@ func (h *MultipartFormDataHandler) BuildRequestBody(bodyParams map[string]any) (
 		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	// Note: The boundary should be set in the Content-Type header by the caller
-	// It can be retrieved via writer.Boundary()
-
-	return &body, nil
+	return &multipartBody{Buffer: &body, contentType: writer.FormDataContentType()}, nil
 }
 
 // PlainTextHandler handles text/plain and text/* content types
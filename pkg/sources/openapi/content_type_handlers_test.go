@@ -0,0 +1,212 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const negotiationSpec = `
+openapi: 3.0.0
+info:
+  title: Negotiation API
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      operationId: createItem
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+          application/xml:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+          content:
+            application/xml:
+              schema:
+                type: object
+            application/json:
+              schema:
+                type: object
+        default:
+          description: Error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+`
+
+func TestContentTypeRegistry_SelectContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []string
+		declared    []string
+		expected    string
+	}{
+		{"default prefers JSON", nil, []string{"text/plain", "application/xml", "application/json"}, "application/json"},
+		{"default prefers concrete over wildcard", nil, []string{"*/*", "application/xml"}, "application/xml"},
+		{"parameters are ignored", nil, []string{"text/plain", "application/json; charset=utf-8"}, "application/json; charset=utf-8"},
+		{"custom preferences", []string{"application/xml"}, []string{"application/json", "application/xml"}, "application/xml"},
+		{"unknown keeps declaration order", nil, []string{"application/x-custom", "application/x-other"}, "application/x-custom"},
+		{"nothing declared", nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewContentTypeRegistry()
+			if tt.preferences != nil {
+				registry.SetPreferences(tt.preferences)
+			}
+			// Selection must be deterministic across repeated calls
+			for range 20 {
+				if got := registry.SelectContentType(tt.declared); got != tt.expected {
+					t.Fatalf("Expected %q, got %q", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestContentTypeRegistry_SetPreferences_Reset(t *testing.T) {
+	registry := NewContentTypeRegistry()
+	registry.SetPreferences([]string{"application/xml"})
+	registry.SetPreferences(nil)
+	if got := registry.SelectContentType([]string{"application/xml", "application/json"}); got != "application/json" {
+		t.Errorf("Expected default preferences to be restored, got %q", got)
+	}
+}
+
+func TestContentTypeRegistry_SortContentTypes(t *testing.T) {
+	registry := NewContentTypeRegistry()
+	got := registry.SortContentTypes([]string{"application/x-custom", "text/plain", "application/xml", "application/json"})
+	expected := []string{"application/json", "application/xml", "text/plain", "application/x-custom"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateToolFromOperation_ContentTypeNegotiation(t *testing.T) {
+	tool := createToolsFromSpec(t, negotiationSpec)["createitem"]
+	if tool == nil {
+		t.Fatal("Expected tool createitem")
+	}
+	if tool.OpenAPIHandlerInput.ContentType != "application/json" {
+		t.Errorf("Expected request content type application/json, got %s", tool.OpenAPIHandlerInput.ContentType)
+	}
+	expectedAccept := "application/json, application/xml, application/problem+json"
+	if tool.OpenAPIHandlerInput.Accept != expectedAccept {
+		t.Errorf("Expected Accept %q, got %q", expectedAccept, tool.OpenAPIHandlerInput.Accept)
+	}
+}
+
+func TestCreateToolFromOperation_ContentTypeOverride(t *testing.T) {
+	adapter := NewLibopenAPIAdapter()
+	adapter.contentTypeOverrides = map[string]string{"createitem": "application/xml"}
+	tool := createToolsFromSpecWithAdapter(t, adapter, negotiationSpec)["createitem"]
+	if tool.OpenAPIHandlerInput.ContentType != "application/xml" {
+		t.Errorf("Expected overridden content type application/xml, got %s", tool.OpenAPIHandlerInput.ContentType)
+	}
+
+	// Overrides the operation does not declare fall back to the preference order
+	adapter.contentTypeOverrides = map[string]string{"createitem": "multipart/form-data"}
+	tool = createToolsFromSpecWithAdapter(t, adapter, negotiationSpec)["createitem"]
+	if tool.OpenAPIHandlerInput.ContentType != "application/json" {
+		t.Errorf("Expected fallback content type application/json, got %s", tool.OpenAPIHandlerInput.ContentType)
+	}
+}
+
+func TestOpenAPISource_Parse_ResetsContentTypePreferences(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specFile, []byte(negotiationSpec), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	source := NewOpenAPISource()
+	parse := func(preferences []string) string {
+		params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+		params.Specs = specFile
+		params.BaseURL = "https://api.example.com"
+		params.DevMode = true
+		params.ContentTypePreferences = preferences
+		app, err := source.Parse(params)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		for _, tool := range app.Tools {
+			if tool.GetName() == "createitem" {
+				return tool.(*OpenAPIMcpTool).OpenAPIHandlerInput.ContentType
+			}
+		}
+		t.Fatal("Expected tool createitem")
+		return ""
+	}
+
+	if got := parse([]string{"application/xml"}); got != "application/xml" {
+		t.Errorf("Expected preferred content type application/xml, got %s", got)
+	}
+	// Parsing again without preferences, e.g. when reloading, uses the default preferences
+	if got := parse(nil); got != "application/json" {
+		t.Errorf("Expected default content type application/json, got %s", got)
+	}
+}
+
+func TestGetOpenAPIHandler_SendsAcceptHeader(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+	}))
+	defer server.Close()
+
+	tool := newTestTool("GET", "/items")
+	tool.OpenAPIHandlerInput.Accept = "application/json, application/xml"
+	handler := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))
+
+	if _, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", map[string]any{}, "")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if accept != "application/json, application/xml" {
+		t.Errorf("Expected derived Accept header, got %q", accept)
+	}
+
+	// Explicit header parameters take precedence
+	params := map[string]any{"header__Accept": "text/csv"}
+	if _, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", params, "")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if accept != "text/csv" {
+		t.Errorf("Expected explicit Accept header, got %q", accept)
+	}
+}
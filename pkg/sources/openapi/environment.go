@@ -0,0 +1,135 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Credential types supported to authenticate API requests.
+const (
+	CredentialsBearer = "bearer"
+	CredentialsBasic  = "basic"
+	CredentialsAPIKey = "apiKey"
+)
+
+// defaultAPIKeyHeader is the header apiKey credentials are sent in if none is configured.
+const defaultAPIKeyHeader = "X-API-Key"
+
+// Credentials reference a secret in an environment variable used to authenticate API requests,
+// so the secret itself is never stored in the config file.
+type Credentials struct {
+	Type   string `json:"type"`             // bearer, basic or apiKey
+	Env    string `json:"env"`              // Environment variable holding the token, user:password or API key
+	Header string `json:"header,omitempty"` // Header apiKey credentials are sent in, defaults to X-API-Key
+}
+
+// Validate checks the credential type and that an environment variable is referenced.
+func (c *Credentials) Validate() error {
+	if !slices.Contains([]string{CredentialsBearer, CredentialsBasic, CredentialsAPIKey}, c.Type) {
+		return fmt.Errorf("invalid credentials type %q, must be one of %s, %s or %s",
+			c.Type, CredentialsBearer, CredentialsBasic, CredentialsAPIKey)
+	}
+	if c.Env == "" {
+		return fmt.Errorf("credentials must reference an environment variable holding the secret")
+	}
+	return nil
+}
+
+// header reads the referenced secret and returns the header authenticating requests with it.
+func (c *Credentials) header() (string, string, error) {
+	secret := os.Getenv(c.Env)
+	if secret == "" {
+		return "", "", fmt.Errorf("environment variable %s of the %s credentials is not set", c.Env, c.Type)
+	}
+	switch c.Type {
+	case CredentialsBearer:
+		return "Authorization", "Bearer " + secret, nil
+	case CredentialsBasic:
+		if !strings.Contains(secret, ":") {
+			return "", "", fmt.Errorf("environment variable %s of the basic credentials must have the format user:password", c.Env)
+		}
+		return "Authorization", "Basic " + base64.StdEncoding.EncodeToString([]byte(secret)), nil
+	default:
+		if c.Header != "" {
+			return c.Header, secret, nil
+		}
+		return defaultAPIKeyHeader, secret, nil
+	}
+}
+
+// Environment overrides the parameters of the API a config is used against, e.g. staging or production.
+type Environment struct {
+	BaseURL     string            `json:"baseURL,omitempty"`     // Base URL of the API in this environment
+	Timeout     int               `json:"timeout,omitempty"`     // HTTP timeout in seconds
+	Headers     map[string]string `json:"headers,omitempty"`     // Headers added to or replacing the default headers
+	Credentials *Credentials      `json:"credentials,omitempty"` // Credentials replacing the default credentials
+}
+
+// SelectEnvironment merges the named environment into the params, an empty name keeps them unchanged.
+func (p *OpenAPIParams) SelectEnvironment(name string) error {
+	if name == "" {
+		return nil
+	}
+	environment, ok := p.Environments[name]
+	if !ok {
+		if len(p.Environments) == 0 {
+			return fmt.Errorf("unknown environment %q, the config file defines no environments", name)
+		}
+		return fmt.Errorf("unknown environment %q, must be one of %s",
+			name, strings.Join(slices.Sorted(maps.Keys(p.Environments)), ", "))
+	}
+
+	if environment.BaseURL != "" {
+		p.BaseURL = environment.BaseURL
+	}
+	if environment.Timeout > 0 {
+		p.Timeout = environment.Timeout
+	}
+	if len(environment.Headers) > 0 {
+		headers := maps.Clone(p.Headers)
+		if headers == nil {
+			headers = make(map[string]string, len(environment.Headers))
+		}
+		maps.Copy(headers, environment.Headers)
+		p.Headers = headers
+	}
+	if environment.Credentials != nil {
+		p.Credentials = environment.Credentials
+	}
+	return nil
+}
+
+// getDefaultHeaders returns the configured headers and credentials sent with every API request.
+func getDefaultHeaders(params *OpenAPIParams) (http.Header, error) {
+	headers := make(http.Header, len(params.Headers)+1)
+	for name, value := range params.Headers {
+		headers.Set(name, value)
+	}
+	if params.Credentials != nil {
+		name, value, err := params.Credentials.header()
+		if err != nil {
+			return nil, err
+		}
+		headers.Set(name, value)
+	}
+	return headers, nil
+}
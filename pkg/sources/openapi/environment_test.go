@@ -0,0 +1,250 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+func newEnvironmentParams() *OpenAPIParams {
+	params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.Specs = "spec.json"
+	params.BaseURL = "https://dev.example.com"
+	params.Headers = map[string]string{"X-Tenant": "acme", "X-Debug": "true"}
+	params.Credentials = &Credentials{Type: CredentialsBearer, Env: "DEV_TOKEN"}
+	params.Environments = map[string]Environment{
+		"staging": {BaseURL: "https://staging.example.com"},
+		"prod": {
+			BaseURL:     "https://api.example.com",
+			Timeout:     10,
+			Headers:     map[string]string{"X-Debug": "false"},
+			Credentials: &Credentials{Type: CredentialsAPIKey, Env: "PROD_KEY", Header: "X-Key"},
+		},
+	}
+	return params
+}
+
+func TestOpenAPIParams_SelectEnvironment(t *testing.T) {
+	tests := []struct {
+		name            string
+		environment     string
+		expectError     string
+		expectBaseURL   string
+		expectTimeout   int
+		expectDebug     string
+		expectCredsEnv  string
+		expectTenantSet bool
+	}{
+		{
+			name:            "No environment",
+			expectBaseURL:   "https://dev.example.com",
+			expectTimeout:   30,
+			expectDebug:     "true",
+			expectCredsEnv:  "DEV_TOKEN",
+			expectTenantSet: true,
+		},
+		{
+			name:            "Partial environment",
+			environment:     "staging",
+			expectBaseURL:   "https://staging.example.com",
+			expectTimeout:   30,
+			expectDebug:     "true",
+			expectCredsEnv:  "DEV_TOKEN",
+			expectTenantSet: true,
+		},
+		{
+			name:            "Full environment",
+			environment:     "prod",
+			expectBaseURL:   "https://api.example.com",
+			expectTimeout:   10,
+			expectDebug:     "false",
+			expectCredsEnv:  "PROD_KEY",
+			expectTenantSet: true,
+		},
+		{
+			name:        "Unknown environment",
+			environment: "qa",
+			expectError: "must be one of prod, staging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := newEnvironmentParams()
+			err := params.SelectEnvironment(tt.environment)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if params.BaseURL != tt.expectBaseURL || params.Timeout != tt.expectTimeout {
+				t.Errorf("Expected base URL %s and timeout %d, got %s and %d",
+					tt.expectBaseURL, tt.expectTimeout, params.BaseURL, params.Timeout)
+			}
+			if params.Headers["X-Debug"] != tt.expectDebug || (params.Headers["X-Tenant"] == "acme") != tt.expectTenantSet {
+				t.Errorf("Expected merged headers, got %v", params.Headers)
+			}
+			if params.Credentials.Env != tt.expectCredsEnv {
+				t.Errorf("Expected credentials from %s, got %s", tt.expectCredsEnv, params.Credentials.Env)
+			}
+		})
+	}
+
+	t.Run("Environment headers do not modify the environment", func(t *testing.T) {
+		params := newEnvironmentParams()
+		params.Headers = nil
+		if err := params.SelectEnvironment("prod"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		params.Headers["X-Other"] = "1"
+		if _, exists := params.Environments["prod"].Headers["X-Other"]; exists {
+			t.Error("Expected the headers of the environment to be copied")
+		}
+	})
+
+	t.Run("No environments defined", func(t *testing.T) {
+		params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+		if err := params.SelectEnvironment("prod"); err == nil || !strings.Contains(err.Error(), "defines no environments") {
+			t.Errorf("Expected error for missing environments, got %v", err)
+		}
+	})
+}
+
+func TestCredentials_Header(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "secret")
+	t.Setenv("TEST_BASIC", "user:pass")
+	t.Setenv("TEST_INVALID_BASIC", "nopassword")
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		expectName  string
+		expectValue string
+		expectError bool
+	}{
+		{"Bearer", Credentials{Type: CredentialsBearer, Env: "TEST_TOKEN"}, "Authorization", "Bearer secret", false},
+		{"Basic", Credentials{Type: CredentialsBasic, Env: "TEST_BASIC"}, "Authorization", "Basic dXNlcjpwYXNz", false},
+		{"API key default header", Credentials{Type: CredentialsAPIKey, Env: "TEST_TOKEN"}, "X-API-Key", "secret", false},
+		{"API key custom header", Credentials{Type: CredentialsAPIKey, Env: "TEST_TOKEN", Header: "X-Key"}, "X-Key", "secret", false},
+		{"Unset variable", Credentials{Type: CredentialsBearer, Env: "TEST_UNSET_TOKEN"}, "", "", true},
+		{"Basic without password", Credentials{Type: CredentialsBasic, Env: "TEST_INVALID_BASIC"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, value, err := tt.credentials.header()
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if name != tt.expectName || value != tt.expectValue {
+				t.Errorf("Expected header %s: %s, got %s: %s", tt.expectName, tt.expectValue, name, value)
+			}
+		})
+	}
+}
+
+func TestOpenAPIParams_ValidateCredentials(t *testing.T) {
+	params := newEnvironmentParams()
+	if err := params.Validate(); err != nil {
+		t.Fatalf("Expected valid params, got: %v", err)
+	}
+
+	params.Credentials = &Credentials{Type: "oauth", Env: "TOKEN"}
+	if err := params.Validate(); err == nil {
+		t.Error("Expected error for unsupported credentials type")
+	}
+
+	params = newEnvironmentParams()
+	params.Environments["prod"] = Environment{Credentials: &Credentials{Type: CredentialsBearer}}
+	if err := params.Validate(); err == nil || !strings.Contains(err.Error(), "environment prod") {
+		t.Errorf("Expected error for credentials without variable, got %v", err)
+	}
+}
+
+func TestOpenAPISource_UnmarshalConfig_Environment(t *testing.T) {
+	config := `{
+		"name": "Test API",
+		"version": "1.0.0",
+		"sourceType": "openapi",
+		"tools": [],
+		"config": {
+			"type": "openapi",
+			"specs": "spec.json",
+			"baseURL": "https://dev.example.com",
+			"timeout": 30,
+			"environments": {"prod": {"baseURL": "https://api.example.com"}}
+		}
+	}`
+	source := NewOpenAPISource()
+
+	app, err := source.UnmarshalConfig([]byte(config), "prod")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if baseURL := app.AppParams.(*OpenAPIParams).BaseURL; baseURL != "https://api.example.com" {
+		t.Errorf("Expected base URL of the prod environment, got %s", baseURL)
+	}
+
+	if _, err := source.UnmarshalConfig([]byte(config), "qa"); err == nil {
+		t.Error("Expected error for unknown environment")
+	}
+}
+
+func TestGetOpenAPIHandler_SendsDefaultHeaders(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "secret")
+	var authorization, tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		tenant = r.Header.Get("X-Tenant")
+	}))
+	defer server.Close()
+
+	params := newEnvironmentParams()
+	params.Credentials = &Credentials{Type: CredentialsBearer, Env: "TEST_TOKEN"}
+	headers, err := getDefaultHeaders(params)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	client := NewAPIClient(server.URL, 5)
+	client.Headers = headers
+	handler := GetOpenAPIHandler(newTestTool("GET", "/reports"), client)
+
+	request := core.NewBasicExecutionContext("test_tool", map[string]any{"header__X-Tenant": "other"}, "")
+	if _, err := handler(context.Background(), request); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("Expected bearer credentials, got %q", authorization)
+	}
+	if tenant != "other" {
+		t.Errorf("Expected header parameter of the tool to take precedence, got %q", tenant)
+	}
+}
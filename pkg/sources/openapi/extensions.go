@@ -0,0 +1,216 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// Vendor extensions API owners can use to control how operations are exposed as MCP tools.
+const (
+	extensionName        = "x-mcp-name"         // Operation: tool name, overriding the naming strategy
+	extensionDescription = "x-mcp-description"  // Operation or parameter: description shown to the model
+	extensionHidden      = "x-mcp-hidden"       // Operation or parameter: do not expose to the model
+	extensionAnnotations = "x-mcp-annotations"  // Operation: overrides of the derived tool annotations
+	extensionFixedParams = "x-mcp-fixed-params" // Operation: parameter values that are always sent
+	extensionFixedValue  = "x-mcp-fixed-value"  // Parameter: value that is always sent
+	extensionAsync       = "x-mcp-async"        // Operation: polling of 202 Accepted responses
+)
+
+// mcpAnnotationsExtension holds the overrides of the x-mcp-annotations extension.
+type mcpAnnotationsExtension struct {
+	Title       string `json:"title,omitempty"`
+	ReadOnly    *bool  `json:"readOnly,omitempty"`
+	Destructive *bool  `json:"destructive,omitempty"`
+	Idempotent  *bool  `json:"idempotent,omitempty"`
+	OpenWorld   *bool  `json:"openWorld,omitempty"`
+}
+
+// isHiddenOperation returns true if the operation is excluded via x-mcp-hidden.
+func isHiddenOperation(operation *v3.Operation) bool {
+	var hidden bool
+	if _, err := decodeExtension(operation.Extensions, extensionHidden, &hidden); err != nil {
+		log.Printf("Ignoring invalid %s extension on operation %s: %v", extensionHidden, operation.OperationId, err)
+		return false
+	}
+	return hidden
+}
+
+// isHiddenParameter returns true if the parameter is excluded via x-mcp-hidden.
+func isHiddenParameter(param *v3.Parameter) bool {
+	var hidden bool
+	if _, err := decodeExtension(param.Extensions, extensionHidden, &hidden); err != nil {
+		log.Printf("Ignoring invalid %s extension on parameter %s: %v", extensionHidden, param.Name, err)
+		return false
+	}
+	return hidden
+}
+
+// getExtensionString returns a string vendor extension of an operation, or "" if not set.
+func getExtensionString(operation *v3.Operation, name string) string {
+	var value string
+	if _, err := decodeExtension(operation.Extensions, name, &value); err != nil {
+		log.Printf("Ignoring invalid %s extension on operation %s: %v", name, operation.OperationId, err)
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// getParameterDescription returns the description of a parameter, preferring x-mcp-description.
+func getParameterDescription(param *v3.Parameter) string {
+	var description string
+	if _, err := decodeExtension(param.Extensions, extensionDescription, &description); err != nil || description == "" {
+		return param.Description
+	}
+	return description
+}
+
+// applyAnnotationOverrides applies the x-mcp-annotations extension of an operation to annotation.
+func applyAnnotationOverrides(operation *v3.Operation, annotation *core.McpToolAnnotation) {
+	var overrides mcpAnnotationsExtension
+	found, err := decodeExtension(operation.Extensions, extensionAnnotations, &overrides)
+	if err != nil {
+		log.Printf("Ignoring invalid %s extension on operation %s: %v", extensionAnnotations, operation.OperationId, err)
+		return
+	}
+	if !found {
+		return
+	}
+	if overrides.Title != "" {
+		annotation.Title = overrides.Title
+	}
+	if overrides.ReadOnly != nil {
+		annotation.ReadOnlyHint = overrides.ReadOnly
+	}
+	if overrides.Destructive != nil {
+		annotation.DestructiveHint = overrides.Destructive
+	}
+	if overrides.Idempotent != nil {
+		annotation.IdempotentHint = overrides.Idempotent
+	}
+	if overrides.OpenWorld != nil {
+		annotation.OpenWorldHint = overrides.OpenWorld
+	}
+}
+
+// getFixedParams collects the fixed parameter values of an operation, keyed by prefixed parameter name.
+// Keys of x-mcp-fixed-params are either prefixed (query__version) or plain parameter names,
+// which are resolved against the operation's parameters and fall back to body fields (see removeFixedParams).
+// Values of x-mcp-fixed-value on parameters take precedence.
+func getFixedParams(operation *v3.Operation) map[string]any {
+	fixedParams := make(map[string]any)
+
+	var declared map[string]any
+	if _, err := decodeExtension(operation.Extensions, extensionFixedParams, &declared); err != nil {
+		log.Printf("Ignoring invalid %s extension on operation %s: %v", extensionFixedParams, operation.OperationId, err)
+	}
+	for name, value := range declared {
+		fixedParams[resolveFixedParamName(operation, name)] = value
+	}
+
+	for _, param := range operation.Parameters {
+		if param == nil {
+			continue
+		}
+		var value any
+		found, err := decodeExtension(param.Extensions, extensionFixedValue, &value)
+		if err != nil {
+			log.Printf("Ignoring invalid %s extension on parameter %s: %v", extensionFixedValue, param.Name, err)
+			continue
+		}
+		if found {
+			fixedParams[fmt.Sprintf("%s__%s", param.In, param.Name)] = value
+		}
+	}
+
+	if len(fixedParams) == 0 {
+		return nil
+	}
+	return fixedParams
+}
+
+// resolveFixedParamName returns the prefixed tool parameter name for a key of x-mcp-fixed-params.
+func resolveFixedParamName(operation *v3.Operation, name string) string {
+	if location, _, found := strings.Cut(name, "__"); found && ParameterLocation(location).IsValid() {
+		return name
+	}
+	for _, param := range operation.Parameters {
+		if param != nil && param.Name == name {
+			return fmt.Sprintf("%s__%s", param.In, param.Name)
+		}
+	}
+	return fmt.Sprintf("%s__%s", ParameterLocationBody, name)
+}
+
+// removeFixedParams removes the fixed parameters from the input schema of a tool.
+// Fixed body fields are resolved against the schema properties, since the form and multipart
+// content type handlers name them body__form__<name> and body__multipart__<name>.
+// It returns the fixed parameters keyed by the resolved names.
+func removeFixedParams(schema *core.McpToolInputSchema, fixedParams map[string]any) map[string]any {
+	if len(fixedParams) == 0 {
+		return fixedParams
+	}
+	resolved := make(map[string]any, len(fixedParams))
+	for name, value := range fixedParams {
+		resolved[resolveFixedBodyParamName(schema.Properties, name)] = value
+	}
+	for name := range resolved {
+		delete(schema.Properties, name)
+	}
+	schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool {
+		_, fixed := resolved[name]
+		return fixed
+	})
+	return resolved
+}
+
+// resolveFixedBodyParamName returns the schema property of a fixed body field,
+// trying the field as is and with each of the form and multipart prefixes.
+func resolveFixedBodyParamName(properties map[string]any, name string) string {
+	field, found := strings.CutPrefix(name, string(ParameterLocationBody)+"__")
+	if !found {
+		return name
+	}
+	for _, prefix := range append([]string{""}, bodyFieldPrefixes...) {
+		candidate := fmt.Sprintf("%s__%s%s", ParameterLocationBody, prefix, field)
+		if _, exists := properties[candidate]; exists {
+			return candidate
+		}
+	}
+	return name
+}
+
+// withFixedParams returns the tool call arguments with all fixed parameters applied.
+// Fixed values always win over values provided by the model.
+func withFixedParams(args map[string]any, fixedParams map[string]any) map[string]any {
+	if len(fixedParams) == 0 {
+		return args
+	}
+	result := make(map[string]any, len(args)+len(fixedParams))
+	for name, value := range args {
+		result[name] = value
+	}
+	for name, value := range fixedParams {
+		result[name] = value
+	}
+	return result
+}
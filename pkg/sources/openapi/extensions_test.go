@@ -0,0 +1,272 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const extensionsSpec = `
+openapi: 3.0.0
+info:
+  title: Extensions API
+  version: 1.0.0
+paths:
+  /reports:
+    get:
+      operationId: listReports
+      x-mcp-name: find-reports
+      x-mcp-description: Find reports by owner.
+      description: Lists reports.
+      parameters:
+        - name: owner
+          in: query
+          description: Owner ID
+          x-mcp-description: Login of the report owner
+          schema:
+            type: string
+        - name: api-version
+          in: query
+          required: true
+          x-mcp-fixed-value: "2024-01-01"
+          schema:
+            type: string
+        - name: debug
+          in: query
+          x-mcp-hidden: true
+          schema:
+            type: boolean
+      responses:
+        '200':
+          description: Success
+    post:
+      operationId: createReport
+      x-mcp-annotations:
+        idempotent: true
+        destructive: false
+        title: Create report
+      x-mcp-fixed-params:
+        tenant: acme
+        header__X-Client: makemcp
+      parameters:
+        - name: tenant
+          in: query
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                title:
+                  type: string
+      responses:
+        '201':
+          description: Created
+    delete:
+      operationId: purgeReports
+      x-mcp-hidden: true
+      responses:
+        '204':
+          description: Deleted
+`
+
+func TestCreateToolsFromDocument_Extensions(t *testing.T) {
+	tools := createToolsFromSpec(t, extensionsSpec)
+
+	if _, ok := tools["purgereports"]; ok {
+		t.Error("Expected operation with x-mcp-hidden to be skipped")
+	}
+	if len(tools) != 2 {
+		t.Fatalf("Expected 2 tools, got %d: %v", len(tools), tools)
+	}
+
+	find := tools["find_reports"]
+	if find == nil {
+		t.Fatalf("Expected tool named by x-mcp-name, got %v", tools)
+	}
+	if !strings.HasPrefix(find.Description, "Find reports by owner.") {
+		t.Errorf("Expected description from x-mcp-description, got %q", find.Description)
+	}
+	owner, ok := find.InputSchema.Properties["query__owner"].(map[string]any)
+	if !ok || owner["description"] != "Login of the report owner" {
+		t.Errorf("Expected parameter description from x-mcp-description, got %v", owner)
+	}
+	for _, name := range []string{"query__debug", "query__api-version"} {
+		if _, exists := find.InputSchema.Properties[name]; exists {
+			t.Errorf("Expected %s to be removed from the input schema", name)
+		}
+	}
+	if len(find.InputSchema.Required) != 0 {
+		t.Errorf("Expected fixed parameters not to be required, got %v", find.InputSchema.Required)
+	}
+	if find.OpenAPIHandlerInput.FixedParams["query__api-version"] != "2024-01-01" {
+		t.Errorf("Expected fixed value from x-mcp-fixed-value, got %v", find.OpenAPIHandlerInput.FixedParams)
+	}
+
+	create := tools["createreport"]
+	if create == nil {
+		t.Fatalf("Expected tool createreport, got %v", tools)
+	}
+	annotations := create.Annotations
+	if annotations.Title != "Create report" {
+		t.Errorf("Expected title override, got %q", annotations.Title)
+	}
+	if annotations.IdempotentHint == nil || !*annotations.IdempotentHint {
+		t.Error("Expected idempotent hint override to be true")
+	}
+	if annotations.DestructiveHint == nil || *annotations.DestructiveHint {
+		t.Error("Expected destructive hint override to be false")
+	}
+	expectedFixed := map[string]any{"query__tenant": "acme", "header__X-Client": "makemcp"}
+	for name, value := range expectedFixed {
+		if create.OpenAPIHandlerInput.FixedParams[name] != value {
+			t.Errorf("Expected fixed param %s=%v, got %v", name, value, create.OpenAPIHandlerInput.FixedParams)
+		}
+	}
+	if _, exists := create.InputSchema.Properties["query__tenant"]; exists {
+		t.Error("Expected fixed query parameter to be removed from the input schema")
+	}
+}
+
+func TestCreateToolsFromDocument_FixedFormParams(t *testing.T) {
+	tools := createToolsFromSpec(t, `
+openapi: 3.0.0
+info:
+  title: Token API
+  version: 1.0.0
+paths:
+  /token:
+    post:
+      operationId: createToken
+      x-mcp-fixed-params:
+        client_id: makemcp
+        body__grant_type: password
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              required: [grant_type, username]
+              properties:
+                grant_type:
+                  type: string
+                client_id:
+                  type: string
+                username:
+                  type: string
+      responses:
+        '200':
+          description: Success
+  /avatar:
+    post:
+      operationId: uploadAvatar
+      x-mcp-fixed-params:
+        visibility: public
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                visibility:
+                  type: string
+                file:
+                  type: string
+                  format: binary
+      responses:
+        '200':
+          description: Success
+`)
+
+	tests := []struct {
+		tool     string
+		expected map[string]any
+		kept     []string
+	}{
+		{
+			tool:     "createtoken",
+			expected: map[string]any{"body__form__client_id": "makemcp", "body__form__grant_type": "password"},
+			kept:     []string{"body__form__username"},
+		},
+		{
+			tool:     "uploadavatar",
+			expected: map[string]any{"body__multipart__visibility": "public"},
+			kept:     []string{"body__multipart__file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			tool := tools[tt.tool]
+			if tool == nil {
+				t.Fatalf("Expected tool %s, got %v", tt.tool, tools)
+			}
+			if len(tool.OpenAPIHandlerInput.FixedParams) != len(tt.expected) {
+				t.Errorf("Expected fixed params %v, got %v", tt.expected, tool.OpenAPIHandlerInput.FixedParams)
+			}
+			for name, value := range tt.expected {
+				if tool.OpenAPIHandlerInput.FixedParams[name] != value {
+					t.Errorf("Expected fixed param %s=%v, got %v", name, value, tool.OpenAPIHandlerInput.FixedParams)
+				}
+				if _, exists := tool.InputSchema.Properties[name]; exists {
+					t.Errorf("Expected %s to be removed from the input schema", name)
+				}
+				if slices.Contains(tool.InputSchema.Required, name) {
+					t.Errorf("Expected %s not to be required, got %v", name, tool.InputSchema.Required)
+				}
+			}
+			for _, name := range tt.kept {
+				if _, exists := tool.InputSchema.Properties[name]; !exists {
+					t.Errorf("Expected %s to stay in the input schema, got %v", name, tool.InputSchema.Properties)
+				}
+			}
+		})
+	}
+}
+
+func TestGetOpenAPIHandler_AppliesFixedParams(t *testing.T) {
+	var query, client string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		client = r.Header.Get("X-Client")
+	}))
+	defer server.Close()
+
+	tool := newTestTool("GET", "/reports")
+	tool.OpenAPIHandlerInput.FixedParams = map[string]any{"query__tenant": "acme", "header__X-Client": "makemcp"}
+	handler := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))
+
+	params := map[string]any{"query__tenant": "other", "query__owner": "jane"}
+	if _, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", params, "")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !strings.Contains(query, "tenant=acme") || strings.Contains(query, "tenant=other") {
+		t.Errorf("Expected fixed tenant to override the model's value, got query %q", query)
+	}
+	if !strings.Contains(query, "owner=jane") {
+		t.Errorf("Expected model parameters to be kept, got query %q", query)
+	}
+	if client != "makemcp" {
+		t.Errorf("Expected fixed header, got %q", client)
+	}
+}
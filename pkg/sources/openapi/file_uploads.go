@@ -0,0 +1,88 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileReferencePrefix marks file upload values that reference a local file instead of carrying content.
+const fileReferencePrefix = "file:"
+
+// fileUploadDescription is appended to the description of file upload parameters.
+const fileUploadDescription = "File upload: provide the file content base64-encoded, " +
+	"or 'file:<path>' to upload a local file (only if local file uploads are enabled)."
+
+// resolveFileUpload returns the file name and content of a file upload value.
+// Values prefixed with "file:" are read from uploadDir, everything else is decoded as base64
+// (plain or as a data URL). Local files are rejected if uploadDir is empty.
+func resolveFileUpload(fieldName, value, uploadDir string) (string, []byte, error) {
+	if path, isPath := strings.CutPrefix(value, fileReferencePrefix); isPath {
+		return readUploadFile(path, uploadDir)
+	}
+
+	// Strip data URL header, e.g. "data:image/png;base64,"
+	if strings.HasPrefix(value, "data:") {
+		if _, data, found := strings.Cut(value, ";base64,"); found {
+			value = data
+		}
+	}
+
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if content, err := encoding.DecodeString(value); err == nil {
+			return fieldName, content, nil
+		}
+	}
+	return "", nil, fmt.Errorf("multipart file field %s must contain base64-encoded content", fieldName)
+}
+
+// readUploadFile reads a local file for upload, making sure it is located inside uploadDir.
+func readUploadFile(path, uploadDir string) (string, []byte, error) {
+	if uploadDir == "" {
+		return "", nil, errors.New("local file uploads are disabled - set --file-upload-dir to allow them")
+	}
+
+	root, err := filepath.Abs(uploadDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid file upload directory: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return "", nil, fmt.Errorf("invalid file upload directory: %w", err)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	// Resolve symlinks so links cannot point outside the upload directory
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to access upload file: %w", err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", nil, fmt.Errorf("upload file %s is outside of the allowed directory %s", path, uploadDir)
+	}
+
+	content, err := os.ReadFile(resolved)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read upload file: %w", err)
+	}
+	return filepath.Base(resolved), content, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+func TestResolveFileUpload(t *testing.T) {
+	uploadDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(uploadDir, "report.txt"), []byte("local content"), 0o600); err != nil {
+		t.Fatalf("Failed to write upload file: %v", err)
+	}
+	outsideDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outsideDir, "secret.txt"), []byte("secret"), 0o600); err != nil {
+		t.Fatalf("Failed to write outside file: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(outsideDir, "secret.txt"), filepath.Join(uploadDir, "link.txt")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte("hello"))
+
+	tests := []struct {
+		name         string
+		value        string
+		uploadDir    string
+		wantFilename string
+		wantContent  string
+		wantErr      bool
+	}{
+		{"base64 content", encoded, "", "document", "hello", false},
+		{"data URL", "data:text/plain;base64," + encoded, "", "document", "hello", false},
+		{"invalid base64", "not base64!", "", "", "", true},
+		{"local paths disabled", "file:report.txt", "", "", "", true},
+		{"relative local path", "file:report.txt", uploadDir, "report.txt", "local content", false},
+		{"absolute local path", "file:" + filepath.Join(uploadDir, "report.txt"), uploadDir, "report.txt", "local content", false},
+		{"path traversal", "file:../" + filepath.Base(outsideDir) + "/secret.txt", uploadDir, "", "", true},
+		{"symlink escaping upload dir", "file:link.txt", uploadDir, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename, content, err := resolveFileUpload("document", tt.value, tt.uploadDir)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got content %q", string(content))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if filename != tt.wantFilename {
+				t.Errorf("Expected filename %q, got %q", tt.wantFilename, filename)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("Expected content %q, got %q", tt.wantContent, string(content))
+			}
+		})
+	}
+}
+
+func TestGetOpenAPIHandler_MultipartFileUpload(t *testing.T) {
+	var gotName, gotFilename, gotContent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gotName = r.FormValue("name")
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		gotFilename, gotContent = header.Filename, string(content)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	tool := newTestTool("POST", "/upload")
+	tool.OpenAPIHandlerInput.ContentType = "multipart/form-data"
+	tool.OpenAPIHandlerInput.FileFields = []string{"file"}
+
+	params := map[string]any{
+		"body__multipart__file": base64.StdEncoding.EncodeToString([]byte("file content")),
+		"body__multipart__name": "report",
+	}
+	result, err := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))(
+		context.Background(),
+		core.NewBasicExecutionContext("test_tool", params, ""),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if status, _ := result.GetMetadata().Get("httpStatus"); status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %v: %s", status, result.GetContent())
+	}
+	if gotName != "report" {
+		t.Errorf("Expected form field 'report', got %q", gotName)
+	}
+	if gotFilename != "file" || gotContent != "file content" {
+		t.Errorf("Expected uploaded file 'file' with content, got %q: %q", gotFilename, gotContent)
+	}
+}
+
+func TestGetToolInputSchema_FileFields(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Uploads", "version": "1.0"},
+		"paths": {
+			"/upload": {"post": {
+				"operationId": "upload",
+				"requestBody": {"content": {"multipart/form-data": {"schema": {
+					"type": "object",
+					"properties": {
+						"file": {"type": "string", "format": "binary", "description": "Document"},
+						"name": {"type": "string"}
+					}
+				}}}},
+				"responses": {"200": {"description": "ok"}}
+			}}
+		}
+	}`
+	tool := createToolsFromSpec(t, spec)["upload"]
+
+	if strings.Join(tool.OpenAPIHandlerInput.FileFields, ",") != "file" {
+		t.Errorf("Expected file fields [file], got %v", tool.OpenAPIHandlerInput.FileFields)
+	}
+	prop, ok := tool.InputSchema.Properties["body__multipart__file"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected body__multipart__file property, got %v", tool.InputSchema.Properties)
+	}
+	if prop["type"] != "string" {
+		t.Errorf("Expected file input to be a JSON Schema string, got %v", prop["type"])
+	}
+	if !strings.Contains(prop["description"].(string), "base64") {
+		t.Errorf("Expected file upload hint in description, got %v", prop["description"])
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// retryBaseDelay is the delay before the first retry, doubled for every further attempt.
+const retryBaseDelay = 500 * time.Millisecond
+
+// idempotencyHeaderNames are header parameter names recognized as idempotency keys in specs.
+var idempotencyHeaderNames = []string{"idempotency-key", "x-idempotency-key", "idempotency-token"}
+
+// retryableStatusCodes are HTTP status codes that indicate a transient failure.
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// newIdempotencyKey returns a random UUID (version 4) to identify a logical tool call.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms, fall back to a time-based key
+		return fmt.Sprintf("makemcp-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// getIdempotencyKey returns the idempotency key of a tool call.
+// A key supplied by the transport (e.g. via MCP request metadata) is preferred, otherwise a new one is generated.
+func getIdempotencyKey(request core.ToolExecutionContext) string {
+	if key, ok := request.GetMetadata().Get("idempotencyKey"); ok {
+		if keyStr, ok := key.(string); ok && keyStr != "" {
+			return keyStr
+		}
+	}
+	return newIdempotencyKey()
+}
+
+// isIdempotencyHeaderName returns true if name is a well-known idempotency header.
+func isIdempotencyHeaderName(name string) bool {
+	return slices.Contains(idempotencyHeaderNames, strings.ToLower(name))
+}
+
+// isRetryable returns true if req can safely be sent more than once,
+// either because its method is idempotent or because it carries an idempotency key.
+func isRetryable(req *http.Request, idempotencyHeader string) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return idempotencyHeader != "" && req.Header.Get(idempotencyHeader) != ""
+}
+
+// doWithRetries sends req, retrying transient failures up to MaxRetries times if the request is retryable.
+// The request, including its idempotency key header, is sent unchanged on every attempt.
+// Returns the response and the number of attempts made.
+func (c *APIClient) doWithRetries(ctx context.Context, req *http.Request, idempotencyHeader string) (*http.Response, int, error) {
+	maxAttempts := 1
+	if c.MaxRetries > 0 && isRetryable(req, idempotencyHeader) {
+		maxAttempts += c.MaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req)
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return resp, attempt, err
+		}
+
+		wait := retryBaseDelay << (attempt - 1)
+		if err == nil {
+			if !slices.Contains(retryableStatusCodes, resp.StatusCode) {
+				return resp, attempt, nil
+			}
+			wait = retryAfter(resp.Header, wait, defaultPollMaxInterval*time.Millisecond)
+			// Discard the failed response so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			if err := resp.Body.Close(); err != nil {
+				log.Printf("Failed to close response body: %v", err)
+			}
+			log.Printf("Retrying %s %s after HTTP %d (attempt %d of %d)", req.Method, req.URL, resp.StatusCode, attempt+1, maxAttempts)
+		} else {
+			log.Printf("Retrying %s %s after error: %v (attempt %d of %d)", req.Method, req.URL, err, attempt+1, maxAttempts)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, attempt, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		// Rewind the request body for the next attempt
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, attempt, fmt.Errorf("failed to reset request body for retry: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// flakyServer fails the first failures requests with 503 and records all received idempotency keys.
+func flakyServer(failures int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		attempt := len(keys)
+		mu.Unlock()
+
+		if attempt <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestGetOpenAPIHandler_ReusesIdempotencyKeyAcrossRetries(t *testing.T) {
+	server, receivedKeys := flakyServer(1)
+	defer server.Close()
+
+	tool := newTestTool("POST", "/payments")
+	tool.OpenAPIHandlerInput.IdempotencyHeader = "Idempotency-Key"
+	apiClient := NewAPIClient(server.URL, 5)
+	apiClient.MaxRetries = 2
+
+	result, err := GetOpenAPIHandler(tool, apiClient)(
+		context.Background(),
+		core.NewBasicExecutionContext("test_tool", map[string]any{}, ""),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	keys := receivedKeys()
+	if len(keys) != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the same non-empty idempotency key on every attempt, got %v", keys)
+	}
+
+	metadata := result.GetMetadata()
+	if key, _ := metadata.Get("idempotencyKey"); key != keys[0] {
+		t.Errorf("Expected idempotency key %s in metadata, got %v", keys[0], key)
+	}
+	if attempts, _ := metadata.Get("attempts"); attempts != 2 {
+		t.Errorf("Expected 2 attempts in metadata, got %v", attempts)
+	}
+	if status, _ := metadata.Get("httpStatus"); status != http.StatusCreated {
+		t.Errorf("Expected final status 201, got %v", status)
+	}
+}
+
+func TestGetOpenAPIHandler_UsesIdempotencyKeyFromRequestMetadata(t *testing.T) {
+	server, receivedKeys := flakyServer(0)
+	defer server.Close()
+
+	tool := newTestTool("POST", "/payments")
+	tool.OpenAPIHandlerInput.IdempotencyHeader = "Idempotency-Key"
+
+	execContext := core.NewBasicExecutionContext("test_tool", map[string]any{}, "")
+	execContext.GetMetadata().Set("idempotencyKey", "client-key-1")
+
+	if _, err := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))(context.Background(), execContext); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if keys := receivedKeys(); len(keys) != 1 || keys[0] != "client-key-1" {
+		t.Errorf("Expected client supplied key, got %v", keys)
+	}
+}
+
+func TestGetOpenAPIHandler_DoesNotRetryNonIdempotentRequests(t *testing.T) {
+	server, receivedKeys := flakyServer(1)
+	defer server.Close()
+
+	apiClient := NewAPIClient(server.URL, 5)
+	apiClient.MaxRetries = 2
+
+	result, err := GetOpenAPIHandler(newTestTool("POST", "/payments"), apiClient)(
+		context.Background(),
+		core.NewBasicExecutionContext("test_tool", map[string]any{}, ""),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if keys := receivedKeys(); len(keys) != 1 {
+		t.Errorf("Expected a single attempt for POST without idempotency key, got %d", len(keys))
+	}
+	if status, _ := result.GetMetadata().Get("httpStatus"); status != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %v", status)
+	}
+}
+
+func TestGetIdempotencyHeader_DetectedFromSpec(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Payments", "version": "1.0"},
+		"paths": {
+			"/payments": {"post": {
+				"operationId": "create_payment",
+				"parameters": [
+					{"name": "Idempotency-Key", "in": "header", "required": true, "schema": {"type": "string"}},
+					{"name": "X-Tenant", "in": "header", "schema": {"type": "string"}}
+				],
+				"responses": {"201": {"description": "created"}}
+			}}
+		}
+	}`
+	tool := createToolsFromSpec(t, spec)["create_payment"]
+
+	if tool.OpenAPIHandlerInput.IdempotencyHeader != "Idempotency-Key" {
+		t.Errorf("Expected idempotency header to be detected, got %q", tool.OpenAPIHandlerInput.IdempotencyHeader)
+	}
+	if _, exists := tool.InputSchema.Properties["header__Idempotency-Key"]; exists {
+		t.Error("Expected idempotency header to be excluded from the input schema")
+	}
+	if _, exists := tool.InputSchema.Properties["header__X-Tenant"]; !exists {
+		t.Error("Expected other header parameters to remain in the input schema")
+	}
+	for _, required := range tool.InputSchema.Required {
+		if required == "header__Idempotency-Key" {
+			t.Error("Expected idempotency header not to be required")
+		}
+	}
+}
@@ -15,11 +15,12 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -27,7 +28,9 @@ import (
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/renderer"
+	"gopkg.in/yaml.v3"
 
 	core "github.com/T4cceptor/MakeMCP/pkg/core"
 )
@@ -35,13 +38,19 @@ import (
 // LibopenAPIAdapter contains all libopenapi-specific functionality
 // This isolates the library-specific code and makes it easier to swap libraries later
 type LibopenAPIAdapter struct {
-	contentTypeRegistry *ContentTypeRegistry
+	contentTypeRegistry  *ContentTypeRegistry
+	contentTypeOverrides map[string]string // Request content type per tool name, overriding preferences
+	toolNamer            *toolNamer        // Naming strategy for generated tools
+	overlays             []string          // Overlay documents applied to the spec before the model is built
+	maxSpecSize          int64             // Maximum number of bytes read when fetching remote specs
 }
 
 // NewLibopenAPIAdapter creates a new adapter instance
 func NewLibopenAPIAdapter() *LibopenAPIAdapter {
 	return &LibopenAPIAdapter{
 		contentTypeRegistry: NewContentTypeRegistry(),
+		toolNamer:           &toolNamer{strategy: ToolNamingOperationID},
+		maxSpecSize:         DefaultMaxResponseSize,
 	}
 }
 
@@ -55,6 +64,12 @@ func (a *LibopenAPIAdapter) LoadOpenAPISpec(openAPISpecLocation string, strictVa
 		return nil, err
 	}
 
+	// Apply overlays to the raw spec, so they can change anything the model is built from
+	specBytes, err = a.applyOverlays(specBytes, a.overlays)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create document with configuration
 	config := datamodel.NewDocumentConfiguration()
 	config.AllowFileReferences = true
@@ -83,7 +98,20 @@ func (a *LibopenAPIAdapter) LoadOpenAPISpec(openAPISpecLocation string, strictVa
 	return docModel, nil
 }
 
-// loadSpecBytes loads specification bytes from either a file or URL
+// specAcceptHeader asks servers negotiating the spec format for JSON or YAML.
+const specAcceptHeader = "application/json, application/yaml, application/x-yaml, text/yaml;q=0.9, */*;q=0.8"
+
+// yamlContentTypes are the media types YAML specs are served with.
+var yamlContentTypes = []string{
+	"application/yaml",
+	"application/x-yaml",
+	"application/vnd.oai.openapi",
+	"text/yaml",
+	"text/x-yaml",
+}
+
+// loadSpecBytes loads specification bytes from either a file or URL.
+// Specs are JSON or YAML; YAML is detected by content type or a .yaml or .yml extension and checked for syntax errors.
 func (a *LibopenAPIAdapter) loadSpecBytes(openAPISpecLocation string) ([]byte, error) {
 	sourceType := a.detectSourceType(openAPISpecLocation)
 
@@ -93,9 +121,14 @@ func (a *LibopenAPIAdapter) loadSpecBytes(openAPISpecLocation string) ([]byte, e
 		if err != nil {
 			return nil, fmt.Errorf("failed to read OpenAPI spec file: %w", err)
 		}
-		return specBytes, nil
+		return checkSpecBytes(specBytes, openAPISpecLocation, isYAMLLocation(openAPISpecLocation))
 	case "url":
-		resp, err := http.Get(openAPISpecLocation)
+		req, err := http.NewRequest(http.MethodGet, openAPISpecLocation, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for OpenAPI spec: %w", err)
+		}
+		req.Header.Set("Accept", specAcceptHeader)
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch OpenAPI spec from URL: %w", err)
 		}
@@ -104,16 +137,53 @@ func (a *LibopenAPIAdapter) loadSpecBytes(openAPISpecLocation string) ([]byte, e
 				log.Printf("failed to close response body: %v", err)
 			}
 		}()
-		specBytes, err := io.ReadAll(resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, fmt.Errorf("failed to fetch OpenAPI spec from URL: HTTP %d", resp.StatusCode)
+		}
+		mediaType := getMediaType(resp.Header.Get("Content-Type"))
+		if mediaType == "text/html" {
+			return nil, fmt.Errorf("expected a JSON or YAML OpenAPI spec at %s, got an HTML page", openAPISpecLocation)
+		}
+		specBytes, truncated, err := readLimited(resp.Body, a.maxSpecSize)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read OpenAPI spec response: %w", err)
 		}
-		return specBytes, nil
+		if truncated {
+			// A partial spec cannot be parsed reliably, so fail instead of truncating
+			return nil, fmt.Errorf("OpenAPI spec exceeds maximum size of %d bytes", a.maxSpecSize)
+		}
+		isYAML := slices.Contains(yamlContentTypes, mediaType) || isYAMLLocation(req.URL.Path)
+		return checkSpecBytes(specBytes, openAPISpecLocation, isYAML)
 	default:
 		return nil, fmt.Errorf("unknown source type: %s", sourceType)
 	}
 }
 
+// isYAMLLocation returns true if the file or URL path has a YAML extension.
+func isYAMLLocation(location string) bool {
+	ext := strings.ToLower(filepath.Ext(location))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// getMediaType returns the media type of a Content-Type header without parameters.
+func getMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// checkSpecBytes reports syntax errors of YAML specs with their line,
+// instead of the less specific errors of building the document model.
+func checkSpecBytes(specBytes []byte, location string, isYAML bool) ([]byte, error) {
+	if !isYAML {
+		return specBytes, nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(specBytes, &node); err != nil {
+		return nil, fmt.Errorf("invalid YAML in OpenAPI spec %s: %w", location, err)
+	}
+	return specBytes, nil
+}
+
 // detectSourceType determines whether the spec location is a URL or file path
 func (a *LibopenAPIAdapter) detectSourceType(openAPISpecLocation string) string {
 	if strings.HasPrefix(openAPISpecLocation, "http://") || strings.HasPrefix(openAPISpecLocation, "https://") {
@@ -147,42 +217,59 @@ func (a *LibopenAPIAdapter) GetDocumentInfo(doc *libopenapi.DocumentModel[v3.Doc
 }
 
 // CreateToolsFromDocument converts all OpenAPI operations in a document to MCP tools
+// Duplicate tool names are resolved in document order, so the first operation keeps its name.
 func (a *LibopenAPIAdapter) CreateToolsFromDocument(doc *libopenapi.DocumentModel[v3.Document]) ([]OpenAPIMcpTool, error) {
 	var tools []OpenAPIMcpTool
+	toolNames := newToolNameSet()
 
 	err := a.ForEachOperation(doc, func(method, path string, operation *v3.Operation) error {
-		tool := a.createToolFromOperation(method, path, operation)
+		if isHiddenOperation(operation) {
+			log.Printf("Skipping %s %s: hidden via %s", strings.ToUpper(method), path, extensionHidden)
+			return nil
+		}
+		tool := a.createToolFromOperation(method, path, operation, toolNames)
 		tools = append(tools, tool)
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	toolNames.warnCollisions()
 
 	return tools, nil
 }
 
 // createToolFromOperation creates a MakeMCPTool from an OpenAPI operation
-func (a *LibopenAPIAdapter) createToolFromOperation(method, path string, operation *v3.Operation) OpenAPIMcpTool {
-	contentType, _ := a.determineContentType(operation)
+func (a *LibopenAPIAdapter) createToolFromOperation(method, path string, operation *v3.Operation, toolNames *toolNameSet) OpenAPIMcpTool {
 	var resultTool OpenAPIMcpTool = OpenAPIMcpTool{
 		Operation: operation,
+		Tags:      operation.Tags,
 		OpenAPIHandlerInput: &OpenAPIHandlerInput{
-			Method:      method,
-			Path:        path,
-			Headers:     make(map[string]string),
-			Cookies:     make(map[string]string),
-			BodyAppend:  make(map[string]any),
-			ContentType: contentType,
+			Method:     method,
+			Path:       path,
+			Headers:    make(map[string]string),
+			Cookies:    make(map[string]string),
+			BodyAppend: make(map[string]any),
 		},
 	}
-	resultTool.Name = a.getToolName(&resultTool)
+	resultTool.Name = toolNames.claim(a.getToolName(&resultTool), method, path)
+	resultTool.OpenAPIHandlerInput.ContentType, _ = a.determineContentType(operation, resultTool.Name)
+	resultTool.OpenAPIHandlerInput.Accept = a.getAcceptHeader(operation)
+	resultTool.OpenAPIHandlerInput.Async = a.getAsyncPollingConfig(&resultTool)
+	resultTool.OpenAPIHandlerInput.IdempotencyHeader = a.getIdempotencyHeader(&resultTool)
+	resultTool.OpenAPIHandlerInput.FileFields = a.getFileFields(&resultTool)
+	resultTool.OpenAPIHandlerInput.XMLSchema = a.getXMLSchema(&resultTool)
+	resultTool.OpenAPIHandlerInput.FixedParams = getFixedParams(operation)
 	resultTool.InputSchema = a.getToolInputSchema(&resultTool)
+	resultTool.OpenAPIHandlerInput.FixedParams = removeFixedParams(&resultTool.InputSchema, resultTool.OpenAPIHandlerInput.FixedParams)
 	resultTool.Annotations = a.getToolAnnotations(&resultTool)
 
 	// TODO: add proper "GetToolDescription" function which handles everything related to tool description
 	// Create tool description
-	description := operation.Description
+	description := getExtensionString(operation, extensionDescription)
+	if description == "" {
+		description = operation.Description
+	}
 	if description == "" {
 		description = operation.Summary
 	}
@@ -203,25 +290,110 @@ func (a *LibopenAPIAdapter) createToolFromOperation(method, path string, operati
 	return resultTool
 }
 
-// getToolName generates a tool name from operation ID or method and path.
+// getToolName generates a tool name using the configured naming strategy.
+// A name given via x-mcp-name takes precedence over the strategy.
 func (a *LibopenAPIAdapter) getToolName(tool *OpenAPIMcpTool) string {
-	toolName := tool.Operation.OperationId
-	if toolName == "" {
-		toolName = fmt.Sprintf("%s_%s", tool.OpenAPIHandlerInput.Method, tool.OpenAPIHandlerInput.Path)
+	if name := getExtensionString(tool.Operation, extensionName); name != "" {
+		return sanitizeToolName(name)
+	}
+	namer := a.toolNamer
+	if namer == nil {
+		namer = &toolNamer{strategy: ToolNamingOperationID}
 	}
+	return namer.name(tool.OpenAPIHandlerInput.Method, tool.OpenAPIHandlerInput.Path, tool.Operation)
+}
 
-	// Clean up the tool name by removing invalid characters
-	replacer := strings.NewReplacer(
-		// TODO: check for other illegal chars in tool names
-		"{", "",
-		"}", "",
-		"/", "_",
-		"-", "_",
-	)
-	toolName = strings.ToLower(replacer.Replace(toolName))
-	// TODO check tool name convention!
+// getAsyncPollingConfig reads the x-mcp-async extension of an operation.
+// The extension is either a boolean enabling polling with defaults, or an AsyncPollingConfig object.
+func (a *LibopenAPIAdapter) getAsyncPollingConfig(tool *OpenAPIMcpTool) *AsyncPollingConfig {
+	var raw any
+	found, err := decodeExtension(tool.Operation.Extensions, extensionAsync, &raw)
+	if err != nil || !found {
+		if err != nil {
+			log.Printf("Ignoring invalid x-mcp-async extension on %s: %v", tool.Name, err)
+		}
+		return nil
+	}
+	if enabled, ok := raw.(bool); ok {
+		if !enabled {
+			return nil
+		}
+		return &AsyncPollingConfig{}
+	}
+
+	var config AsyncPollingConfig
+	if _, err := decodeExtension(tool.Operation.Extensions, extensionAsync, &config); err != nil {
+		log.Printf("Ignoring invalid x-mcp-async extension on %s: %v", tool.Name, err)
+		return nil
+	}
+	return &config
+}
+
+// getIdempotencyHeader returns the name of the operation's idempotency key header parameter, if any.
+func (a *LibopenAPIAdapter) getIdempotencyHeader(tool *OpenAPIMcpTool) string {
+	for _, param := range tool.Operation.Parameters {
+		if param != nil && param.In == string(ParameterLocationHeader) && isIdempotencyHeaderName(param.Name) {
+			return param.Name
+		}
+	}
+	return ""
+}
 
-	return toolName
+// getFileFields returns the multipart fields of the operation's request body that are file uploads.
+func (a *LibopenAPIAdapter) getFileFields(tool *OpenAPIMcpTool) []string {
+	contentType := tool.OpenAPIHandlerInput.ContentType
+	if !hasRequestBody(tool.Operation) {
+		return nil
+	}
+	if _, isMultipart := a.contentTypeRegistry.GetHandler(contentType).(*MultipartFormDataHandler); !isMultipart {
+		return nil
+	}
+	media, ok := tool.Operation.RequestBody.Content.Get(contentType)
+	if !ok {
+		return nil
+	}
+	return getMultipartFileFields(media)
+}
+
+// getXMLSchema returns the XML hints of the operation's request body if it is structured XML.
+func (a *LibopenAPIAdapter) getXMLSchema(tool *OpenAPIMcpTool) *XMLNode {
+	contentType := tool.OpenAPIHandlerInput.ContentType
+	if !hasRequestBody(tool.Operation) {
+		return nil
+	}
+	if _, isXML := a.contentTypeRegistry.GetHandler(contentType).(*XMLContentTypeHandler); !isXML {
+		return nil
+	}
+	media, ok := tool.Operation.RequestBody.Content.Get(contentType)
+	if !ok || !hasSchemaProps(media) {
+		return nil
+	}
+	return newXMLRootNode(media.Schema)
+}
+
+// decodeExtension decodes the vendor extension with the given name into target using its JSON field names.
+// Returns false if the extension is not present.
+func decodeExtension(extensions *orderedmap.Map[string, *yaml.Node], name string, target any) (bool, error) {
+	if extensions == nil {
+		return false, nil
+	}
+	node, ok := extensions.Get(name)
+	if !ok || node == nil {
+		return false, nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return true, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	// Round-trip through JSON so targets can rely on their json struct tags
+	data, err := json.Marshal(value)
+	if err != nil {
+		return true, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return true, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return true, nil
 }
 
 // getToolInputSchema creates the input schema for a tool.
@@ -233,11 +405,12 @@ func (a *LibopenAPIAdapter) getToolInputSchema(tool *OpenAPIMcpTool) core.McpToo
 	for _, in := range ParameterLocations {
 		props, reqs := a.extractParametersByIn(tool, in)
 		for paramName, prop := range props {
-			prefixedName := fmt.Sprintf("%s__%s", in, paramName)
-			genericProps[prefixedName] = map[string]any{
-				"type":        prop.Type,
-				"description": prop.Description,
+			// Idempotency keys are generated per tool call and not provided by the model
+			if in == ParameterLocationHeader && paramName == tool.OpenAPIHandlerInput.IdempotencyHeader {
+				continue
 			}
+			prefixedName := fmt.Sprintf("%s__%s", in, paramName)
+			genericProps[prefixedName] = getPropertySchema(prop)
 			if slices.Contains(reqs, paramName) {
 				required = append(required, prefixedName)
 			}
@@ -248,9 +421,13 @@ func (a *LibopenAPIAdapter) getToolInputSchema(tool *OpenAPIMcpTool) core.McpToo
 	bodyProps, bodyReqs := a.extractRequestBodyProperties(tool)
 	for paramName, prop := range bodyProps {
 		prefixedName := fmt.Sprintf("body__%s", paramName)
-		genericProps[prefixedName] = map[string]any{
-			"type":        prop.Type,
-			"description": prop.Description,
+		genericProps[prefixedName] = getPropertySchema(prop)
+		if prop.Type == "file" {
+			// File uploads are transported as base64 strings or local file references
+			genericProps[prefixedName] = map[string]any{
+				"type":        "string",
+				"description": strings.TrimSpace(prop.Description + " " + fileUploadDescription),
+			}
 		}
 		if slices.Contains(bodyReqs, paramName) {
 			required = append(required, prefixedName)
@@ -264,7 +441,38 @@ func (a *LibopenAPIAdapter) getToolInputSchema(tool *OpenAPIMcpTool) core.McpToo
 	}
 }
 
+// getPropertySchema returns the JSON schema of a tool input property.
+func getPropertySchema(prop ToolInputProperty) map[string]any {
+	schema := map[string]any{
+		"type":        prop.Type,
+		"description": prop.Description,
+	}
+	if len(prop.Enum) > 0 {
+		schema["enum"] = prop.Enum
+	}
+	return schema
+}
+
+// getSchemaEnum returns the enum values of a schema, or nil if it declares none.
+func getSchemaEnum(schemaProxy *base.SchemaProxy) []any {
+	if schemaProxy == nil {
+		return nil
+	}
+	schema := schemaProxy.Schema()
+	if schema == nil || len(schema.Enum) == 0 {
+		return nil
+	}
+	values := make([]any, 0, len(schema.Enum))
+	for _, node := range schema.Enum {
+		if value, ok := decodeYAMLValue(node); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // getToolAnnotations returns tool annotations based on HTTP method and operation.
+// Hints given via x-mcp-annotations override the derived ones.
 func (a *LibopenAPIAdapter) getToolAnnotations(tool *OpenAPIMcpTool) core.McpToolAnnotation {
 	annotation := core.McpToolAnnotation{Title: tool.Name}
 	switch methodUpper := strings.ToUpper(tool.OpenAPIHandlerInput.Method); methodUpper {
@@ -282,6 +490,7 @@ func (a *LibopenAPIAdapter) getToolAnnotations(tool *OpenAPIMcpTool) core.McpToo
 		// IdempotentHint: POST is not idempotent
 		annotation.IdempotentHint = boolPtr(false)
 	}
+	applyAnnotationOverrides(tool.Operation, &annotation)
 	return annotation
 }
 
@@ -311,11 +520,19 @@ func (a *LibopenAPIAdapter) extractParametersByIn(tool *OpenAPIMcpTool, in Param
 			continue
 		}
 		if param.In == string(in) {
+			if isHiddenParameter(param) {
+				_, fixed := tool.OpenAPIHandlerInput.FixedParams[fmt.Sprintf("%s__%s", in, param.Name)]
+				if param.Required != nil && *param.Required && !fixed {
+					log.Printf("Warning: required parameter %s of %s is hidden via %s", param.Name, tool.Name, extensionHidden)
+				}
+				continue
+			}
 			typeName := GetSchemaTypeString(param.Schema)
 			properties[param.Name] = ToolInputProperty{
 				Type:        typeName,
-				Description: param.Description,
+				Description: getParameterDescription(param),
 				Location:    in,
+				Enum:        getSchemaEnum(param.Schema),
 			}
 			if param.Required != nil && *param.Required {
 				required = append(required, param.Name)
@@ -356,25 +573,61 @@ func (a *LibopenAPIAdapter) generateSchemaDocumentation(schemaProxy *base.Schema
 	return doc.String()
 }
 
-// determineContentType returns the preferred content type for an operation's request body
-func (a *LibopenAPIAdapter) determineContentType(operation *v3.Operation) (string, *v3.MediaType) {
-	if operation.RequestBody == nil || operation.RequestBody.Content == nil {
+// determineContentType returns the preferred content type for an operation's request body.
+// A configured override for the tool takes precedence over the registry's preference order.
+func (a *LibopenAPIAdapter) determineContentType(operation *v3.Operation, toolName string) (string, *v3.MediaType) {
+	if operation.RequestBody == nil || operation.RequestBody.Content == nil || operation.RequestBody.Content.Len() == 0 {
 		return "", nil
 	}
 
-	// Priority order for content types
-	contentTypes := a.contentTypeRegistry.GetAllContentTypes()
-	// TODO: check if the order of content types is correct
-	for _, expectedContentType := range contentTypes {
-		for actualContentType, media := range operation.RequestBody.Content.FromNewest() {
-			if expectedContentType == actualContentType {
-				return actualContentType, media
+	var declared []string
+	for contentPairs := operation.RequestBody.Content.First(); contentPairs != nil; contentPairs = contentPairs.Next() {
+		declared = append(declared, contentPairs.Key())
+	}
+
+	if override, ok := a.contentTypeOverrides[toolName]; ok {
+		for _, contentType := range declared {
+			if normalizeMediaType(contentType) == normalizeMediaType(override) {
+				media, _ := operation.RequestBody.Content.Get(contentType)
+				return contentType, media
+			}
+		}
+		log.Printf("Warning: content type override %s for tool %s is not declared by the operation, using %v", override, toolName, declared)
+	}
+
+	contentType := a.contentTypeRegistry.SelectContentType(declared)
+	media, _ := operation.RequestBody.Content.Get(contentType)
+	return contentType, media
+}
+
+// getAcceptHeader derives the Accept header from the media types of the operation's
+// success and default responses, ordered by content type preference.
+func (a *LibopenAPIAdapter) getAcceptHeader(operation *v3.Operation) string {
+	if operation.Responses == nil {
+		return ""
+	}
+
+	var mediaTypes []string
+	addMediaTypes := func(response *v3.Response) {
+		if response == nil || response.Content == nil {
+			return
+		}
+		for contentPairs := response.Content.First(); contentPairs != nil; contentPairs = contentPairs.Next() {
+			if !slices.Contains(mediaTypes, contentPairs.Key()) {
+				mediaTypes = append(mediaTypes, contentPairs.Key())
+			}
+		}
+	}
+	if operation.Responses.Codes != nil {
+		for codePairs := operation.Responses.Codes.First(); codePairs != nil; codePairs = codePairs.Next() {
+			if strings.HasPrefix(codePairs.Key(), "2") {
+				addMediaTypes(codePairs.Value())
 			}
 		}
 	}
+	addMediaTypes(operation.Responses.Default)
 
-	// Return first available content type if no priority match
-	return operation.RequestBody.Content.First().Key(), operation.RequestBody.Content.First().Value()
+	return strings.Join(a.contentTypeRegistry.SortContentTypes(mediaTypes), ", ")
 }
 
 // extractRequestBodySchemaDoc extracts schema documentation for non-JSON content types
@@ -0,0 +1,87 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// rawBodyParam is the parameter of request bodies without schema, see createFallbackBodyParameter.
+const rawBodyParam = "body__body"
+
+// bodyFieldPrefixes are added to body fields by the form and multipart content type handlers.
+var bodyFieldPrefixes = []string{"form__", "multipart__"}
+
+// Lint reports parameters colliding with the location prefix scheme and structured
+// request bodies without schema, which fall back to a raw body string or are left out.
+func (o *OpenAPIMcpTool) Lint() []core.LintIssue {
+	if o.OpenAPIHandlerInput == nil || o.OpenAPIHandlerInput.Workflow != nil {
+		// Composite tool inputs are not prefixed
+		return nil
+	}
+
+	params := make([]string, 0, len(o.InputSchema.Properties))
+	for param := range o.InputSchema.Properties {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	var issues []core.LintIssue
+	for _, param := range params {
+		location, name, found := strings.Cut(param, "__")
+		if ParameterLocation(location) == ParameterLocationBody {
+			for _, prefix := range bodyFieldPrefixes {
+				name = strings.TrimPrefix(name, prefix)
+			}
+		}
+		if found && ParameterLocation(location).IsValid() && strings.Contains(name, "__") {
+			issues = append(issues, core.LintIssue{
+				Severity: core.LintWarning, Rule: "prefix_collision", Tool: o.Name, Parameter: param,
+				Message: fmt.Sprintf("%s parameter %q contains '__', the separator of the location prefix", location, name),
+			})
+		}
+	}
+
+	if isStructuredContentType(o.OpenAPIHandlerInput.ContentType) {
+		hasBodyParams := false
+		for _, param := range params {
+			hasBodyParams = hasBodyParams || strings.HasPrefix(param, string(ParameterLocationBody)+"__")
+		}
+		switch {
+		case slices.Contains(params, rawBodyParam):
+			issues = append(issues, core.LintIssue{
+				Severity: core.LintWarning, Rule: "untyped_body", Tool: o.Name, Parameter: rawBodyParam,
+				Message: fmt.Sprintf("%s request body has no schema, models have to write it as a raw string", o.OpenAPIHandlerInput.ContentType),
+			})
+		case !hasBodyParams:
+			issues = append(issues, core.LintIssue{
+				Severity: core.LintWarning, Rule: "untyped_body", Tool: o.Name,
+				Message: fmt.Sprintf("%s request body has no schema properties, the tool cannot send a body", o.OpenAPIHandlerInput.ContentType),
+			})
+		}
+	}
+	return issues
+}
+
+// isStructuredContentType returns true for content types whose bodies are built from parameters,
+// as opposed to text and binary content passed as is.
+func isStructuredContentType(contentType string) bool {
+	return contentType != "" && !strings.HasPrefix(contentType, "text/") && contentType != "application/octet-stream"
+}
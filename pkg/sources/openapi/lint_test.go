@@ -0,0 +1,110 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"testing"
+)
+
+func TestOpenAPIMcpTool_Lint(t *testing.T) {
+	tools := createToolsFromSpec(t, `{
+	  "openapi": "3.0.0",
+	  "info": {"title": "Lint API", "version": "1.0.0"},
+	  "paths": {
+	    "/items": {
+	      "post": {
+	        "operationId": "createItem",
+	        "parameters": [
+	          {"name": "filter__name", "in": "query", "schema": {"type": "string"}},
+	          {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+	        ],
+	        "requestBody": {"content": {"application/json": {}}},
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    },
+	    "/reports": {
+	      "post": {
+	        "operationId": "createReport",
+	        "requestBody": {"content": {"application/xml": {}}},
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    },
+	    "/notes": {
+	      "post": {
+	        "operationId": "createNote",
+	        "requestBody": {"content": {"text/plain": {}}},
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    }
+	  }
+	}`)
+
+	issues := tools["createitem"].Lint()
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %v", issues)
+	}
+	if issues[0].Rule != "prefix_collision" || issues[0].Parameter != "query__filter__name" {
+		t.Errorf("Expected prefix collision of query__filter__name, got %+v", issues[0])
+	}
+	if issues[1].Rule != "untyped_body" || issues[1].Parameter != "" {
+		t.Errorf("Expected JSON body without schema to be reported, got %+v", issues[1])
+	}
+
+	issues = tools["createreport"].Lint()
+	if len(issues) != 1 || issues[0].Rule != "untyped_body" || issues[0].Parameter != rawBodyParam {
+		t.Errorf("Expected XML body falling back to a raw string, got %v", issues)
+	}
+
+	if issues := tools["createnote"].Lint(); len(issues) != 0 {
+		t.Errorf("Expected raw text bodies not to be reported, got %v", issues)
+	}
+}
+
+func TestOpenAPIMcpTool_Lint_FormFields(t *testing.T) {
+	tools := createToolsFromSpec(t, `{
+	  "openapi": "3.0.0",
+	  "info": {"title": "Form API", "version": "1.0.0"},
+	  "paths": {
+	    "/login": {
+	      "post": {
+	        "operationId": "login",
+	        "requestBody": {"content": {"application/x-www-form-urlencoded": {"schema": {
+	          "type": "object",
+	          "properties": {"username": {"type": "string"}, "password": {"type": "string"}, "otp__code": {"type": "string"}}
+	        }}}},
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    },
+	    "/avatar": {
+	      "post": {
+	        "operationId": "uploadAvatar",
+	        "requestBody": {"content": {"multipart/form-data": {"schema": {
+	          "type": "object",
+	          "properties": {"file": {"type": "string", "format": "binary"}, "caption": {"type": "string"}}
+	        }}}},
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    }
+	  }
+	}`)
+
+	issues := tools["login"].Lint()
+	if len(issues) != 1 || issues[0].Parameter != "body__form__otp__code" {
+		t.Errorf("Expected only the form field containing '__' to be reported, got %v", issues)
+	}
+	if issues := tools["uploadavatar"].Lint(); len(issues) != 0 {
+		t.Errorf("Expected multipart fields not to be reported, got %v", issues)
+	}
+}
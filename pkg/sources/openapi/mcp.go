@@ -15,11 +15,13 @@
 package openapi
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -48,9 +50,24 @@ func (s *OpenAPISource) ParseParams(input *core.CLIParamsInput) (core.AppParams,
 	return ParseFromCLIInput(input)
 }
 
-// UnmarshalConfig reconstructs a MakeMCPApp from JSON data for the load command
-func (s *OpenAPISource) UnmarshalConfig(data []byte) (*core.MakeMCPApp, error) {
-	return core.UnmarshalConfigWithTypedParams[*OpenAPIMcpTool, *OpenAPIParams](data)
+// UnmarshalConfig reconstructs a MakeMCPApp from JSON data for the load command,
+// merging the given environment of the config into its params.
+func (s *OpenAPISource) UnmarshalConfig(data []byte, environment string) (*core.MakeMCPApp, error) {
+	app, err := core.UnmarshalConfigWithTypedParams[*OpenAPIMcpTool, *OpenAPIParams](data)
+	if err != nil {
+		return nil, err
+	}
+	if environment == "" {
+		return app, nil
+	}
+	openAPIParams, ok := app.AppParams.(*OpenAPIParams)
+	if !ok || openAPIParams == nil {
+		return nil, fmt.Errorf("config file has no OpenAPI parameters to select environment %s", environment)
+	}
+	if err := openAPIParams.SelectEnvironment(environment); err != nil {
+		return nil, err
+	}
+	return app, nil
 }
 
 // Parse converts an OpenAPI specification into a MakeMCPApp configuration
@@ -73,6 +90,15 @@ func (s *OpenAPISource) Parse(params core.AppParams) (*core.MakeMCPApp, error) {
 	if s.adapter == nil {
 		s.adapter = NewLibopenAPIAdapter()
 	}
+	s.adapter.maxSpecSize = openAPIParams.GetMaxResponseSize()
+	s.adapter.contentTypeRegistry.SetPreferences(openAPIParams.ContentTypePreferences)
+	s.adapter.contentTypeOverrides = openAPIParams.ContentTypeOverrides
+	namer, err := newToolNamer(openAPIParams.ToolNaming, openAPIParams.ToolNameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	s.adapter.toolNamer = namer
+	s.adapter.overlays = openAPIParams.Overlays
 
 	// Load the OpenAPI specification
 	doc, err := s.adapter.LoadOpenAPISpec(openAPIParams.Specs, openAPIParams.StrictValidate)
@@ -94,10 +120,32 @@ func (s *OpenAPISource) Parse(params core.AppParams) (*core.MakeMCPApp, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to process operations: %w", err)
 	}
+
+	// Expose Arazzo workflows as composite tools
+	if openAPIParams.Arazzo != "" {
+		workflowTools, err := s.adapter.CreateWorkflowTools(openAPIParams.Arazzo, openAPITools)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process Arazzo workflows: %w", err)
+		}
+		openAPITools = append(openAPITools, workflowTools...)
+	}
 	app.Tools = convertToMakeMCPTools(openAPITools)
+
+	// Guide the model through the API with prompts per tag and example
+	if openAPIParams.Prompts {
+		app.Prompts = s.adapter.CreatePrompts(doc, openAPITools)
+	}
 	return &app, nil
 }
 
+// ParseSpec creates a MakeMCPApp from a spec file or URL without further parameters, e.g. to validate the spec.
+func (s *OpenAPISource) ParseSpec(location string) (*core.MakeMCPApp, error) {
+	params := NewOpenAPIParams(core.NewBaseParams(s.Name(), core.TransportTypeStdio))
+	params.Specs = location
+	params.DevMode = true
+	return s.Parse(params)
+}
+
 func convertToMakeMCPTools(tools []OpenAPIMcpTool) []core.MakeMCPTool {
 	result := make([]core.MakeMCPTool, len(tools))
 	for i := range tools {
@@ -115,12 +163,54 @@ func (s *OpenAPISource) AttachToolHandlers(app *core.MakeMCPApp) error {
 	}
 
 	apiClient := NewAPIClient(openAPIParams.BaseURL, openAPIParams.Timeout)
+	apiClient.MaxResponseSize = openAPIParams.GetMaxResponseSize()
+	apiClient.MaxRetries = openAPIParams.MaxRetries
+	apiClient.FileUploadDir = openAPIParams.FileUploadDir
+	headers, err := getDefaultHeaders(openAPIParams)
+	if err != nil {
+		return err
+	}
+	apiClient.Headers = headers
+	toolsByName := make(map[string]*OpenAPIMcpTool, len(app.Tools))
 	for i, tool := range app.Tools {
 		// TODO: ugly type assertion
 		openApiTool := tool.(*OpenAPIMcpTool)
 		openApiTool.handler = GetOpenAPIHandler(openApiTool, apiClient)
+		if openAPIParams.Resources {
+			if resource := newToolResource(openApiTool, apiClient.BaseURL); resource != nil {
+				openApiTool.resource = resource
+				openApiTool.resourceHandler = GetResourceHandler(openApiTool, resource.variables, apiClient)
+			}
+		}
+		toolsByName[openApiTool.Name] = openApiTool
 		app.Tools[i] = openApiTool
 	}
+
+	// Suggest parameter values from enums and lookup operations
+	completer, err := newCompleter(openAPIParams.CompletionLookups, toolsByName, apiClient)
+	if err != nil {
+		return err
+	}
+	for _, openApiTool := range toolsByName {
+		openApiTool.completer = completer
+	}
+
+	// Composite tools execute the operations of other tools
+	for _, openApiTool := range toolsByName {
+		if openApiTool.OpenAPIHandlerInput != nil && openApiTool.OpenAPIHandlerInput.Workflow != nil {
+			openApiTool.handler = GetWorkflowHandler(openApiTool, toolsByName, apiClient)
+		}
+	}
+
+	// Composite tools of the configuration file chain calls of the tools above
+	compositeTools, err := createCompositeTools(app.CompositeTools, toolsByName)
+	if err != nil {
+		return err
+	}
+	for _, compositeTool := range compositeTools {
+		compositeTool.handler = GetWorkflowHandler(compositeTool, toolsByName, apiClient)
+		app.Tools = append(app.Tools, compositeTool)
+	}
 	return nil
 }
 
@@ -142,17 +232,41 @@ func buildRequestURL(baseURL, path string, params ToolParams) string {
 }
 
 // buildRequestBody prepares the request body for non-GET/DELETE methods using content-type handlers.
-func buildRequestBody(params ToolParams, tool *OpenAPIMcpTool) (io.Reader, error) {
-	if len(params.Body) > 0 {
-		// Use the global content type registry to handle body building
-		registry := NewContentTypeRegistry()
-		handler := registry.GetHandler(tool.OpenAPIHandlerInput.ContentType)
-		return handler.BuildRequestBody(params.Body)
-	}
-	return nil, nil
+// Returns the body and its Content-Type, which includes the boundary for multipart bodies.
+func buildRequestBody(params ToolParams, tool *OpenAPIMcpTool, fileUploadDir string) (io.Reader, string, error) {
+	contentType := tool.OpenAPIHandlerInput.ContentType
+	if len(params.Body) == 0 {
+		return nil, contentType, nil
+	}
+
+	// Use the global content type registry to handle body building
+	registry := NewContentTypeRegistry()
+	handler := registry.GetHandler(contentType)
+	switch handler.(type) {
+	case *MultipartFormDataHandler:
+		// Multipart bodies need to know which fields are file uploads
+		handler = &MultipartFormDataHandler{
+			FileFields:    tool.OpenAPIHandlerInput.FileFields,
+			FileUploadDir: fileUploadDir,
+		}
+	case *XMLContentTypeHandler:
+		// Structured XML bodies are serialized using the schema's xml hints
+		handler = &XMLContentTypeHandler{Schema: tool.OpenAPIHandlerInput.XMLSchema}
+	}
+
+	body, err := handler.BuildRequestBody(params.Body)
+	if err != nil {
+		return nil, contentType, err
+	}
+	if multipart, ok := body.(*multipartBody); ok {
+		// Unwrap to a plain reader so the request body can be replayed on retries
+		return bytes.NewReader(multipart.Bytes()), multipart.ContentType(), nil
+	}
+	return body, contentType, nil
 }
 
 // setRequestHeaders applies headers and cookies to the HTTP request with appropriate content type.
+// For multipart bodies contentType must include the boundary parameter.
 func setRequestHeaders(req *http.Request, params ToolParams, hasBody bool, contentType string) {
 	if hasBody && contentType != "" {
 		req.Header.Set("Content-Type", contentType)
@@ -180,6 +294,154 @@ func setRequestHeaders(req *http.Request, params ToolParams, hasBody bool, conte
 	}
 }
 
+// apiResponse holds the outcome of a single HTTP request against the API.
+type apiResponse struct {
+	method       string
+	url          string
+	statusCode   int
+	header       http.Header
+	body         []byte
+	truncated    bool
+	streamed     bool
+	streamChunks int
+	streamError  error // Set if a streaming response was interrupted, body holds the chunks received before
+	responseTime time.Duration
+	attempts     int
+}
+
+// execute sends the request and reads the response within the configured size limit.
+// Transient failures are retried if the request is idempotent or carries a key in idempotencyHeader.
+// Streaming responses are forwarded chunk by chunk to the notifier, everything else is read at once.
+func (c *APIClient) execute(ctx context.Context, req *http.Request, idempotencyHeader string, notifier core.ProgressNotifier) (*apiResponse, error) {
+	requestStartTime := time.Now()
+	resp, attempts, err := c.doWithRetries(ctx, req, idempotencyHeader)
+	responseTime := time.Since(requestStartTime)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+
+	result := &apiResponse{
+		method:       req.Method,
+		url:          req.URL.String(),
+		statusCode:   resp.StatusCode,
+		header:       resp.Header,
+		responseTime: responseTime,
+		attempts:     attempts,
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if isStreamingContentType(contentType) {
+		// Streams may run longer than the timeout as long as chunks keep arriving
+		allowIdleReads(resp.Body, c.Timeout)
+		stream, err := readStreamingResponse(ctx, resp.Body, contentType, c.MaxResponseSize, notifier)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		if err != nil && stream.chunks == 0 {
+			return nil, fmt.Errorf("failed to read streaming response: %w", err)
+		}
+		if err != nil {
+			// Keep the chunks that were already forwarded to the client
+			log.Printf("Streaming response of %s %s interrupted after %d chunks: %v", req.Method, req.URL, stream.chunks, err)
+			stream.truncated = true
+			result.streamError = err
+		}
+		result.body, result.truncated = stream.data, stream.truncated
+		result.streamed, result.streamChunks = true, stream.chunks
+		return result, nil
+	}
+
+	result.body, result.truncated, err = readLimited(resp.Body, c.MaxResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return result, nil
+}
+
+// operationCall holds the outcome of calling an operation, after following async operations.
+type operationCall struct {
+	response          *apiResponse
+	polled            *pollResult // Set if the response was obtained by polling a status URL
+	idempotencyHeader string
+	idempotencyKey    string
+}
+
+// callOperation builds the HTTP request of an operation from the prefixed tool parameters,
+// executes it and polls the status URL of accepted async operations.
+// Returned errors describe why the call failed and are meant to be reported to the model.
+func (c *APIClient) callOperation(ctx context.Context, makeMcpTool *OpenAPIMcpTool, request core.ToolExecutionContext) (*operationCall, error) {
+	// Parse parameters using prefix approach
+	params := parsePrefixedParameters(withFixedParams(request.GetParameters(), makeMcpTool.OpenAPIHandlerInput.FixedParams))
+	addStaticParams(params, makeMcpTool.OpenAPIHandlerInput)
+	method := makeMcpTool.OpenAPIHandlerInput.Method
+
+	// Build URL and body using helper functions
+	fullURL := buildRequestURL(c.BaseURL, makeMcpTool.OpenAPIHandlerInput.Path, params)
+	bodyReader, requestContentType, err := buildRequestBody(params, makeMcpTool, c.FileUploadDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request body: %w", err)
+	}
+
+	// Create the HTTP request
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Negotiate the response format, explicit header parameters take precedence
+	if accept := makeMcpTool.OpenAPIHandlerInput.Accept; accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	// Send configured headers and credentials, header parameters of the tool take precedence
+	for name, values := range c.Headers {
+		req.Header[name] = slices.Clone(values)
+	}
+
+	// Apply headers and cookies using helper function
+	setRequestHeaders(req, params, bodyReader != nil, requestContentType)
+
+	// Use one idempotency key per logical tool call, reused across retries
+	idempotencyHeader := makeMcpTool.OpenAPIHandlerInput.IdempotencyHeader
+	var idempotencyKey string
+	if idempotencyHeader != "" {
+		idempotencyKey = req.Header.Get(idempotencyHeader)
+		if idempotencyKey == "" {
+			idempotencyKey = getIdempotencyKey(request)
+			req.Header.Set(idempotencyHeader, idempotencyKey)
+		}
+	}
+
+	// Execute request and read the response
+	resp, err := c.execute(ctx, req, idempotencyHeader, request.GetProgressNotifier())
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow long-running operations until they reach a terminal state
+	var polled *pollResult
+	asyncConfig := makeMcpTool.OpenAPIHandlerInput.Async
+	if asyncConfig != nil && resp.statusCode == http.StatusAccepted {
+		polled, err = pollAsyncOperation(ctx, c, req, resp, *asyncConfig, request.GetProgressNotifier())
+		if err != nil {
+			return nil, err
+		}
+		resp = polled.response
+	}
+
+	return &operationCall{
+		response:          resp,
+		polled:            polled,
+		idempotencyHeader: idempotencyHeader,
+		idempotencyKey:    idempotencyKey,
+	}, nil
+}
+
 // GetOpenAPIHandler creates a transport-agnostic MCP tool handler function for an OpenAPI operation.
 //
 // This function returns a handler that processes abstract tool execution contexts and converts them
@@ -202,7 +464,9 @@ func setRequestHeaders(req *http.Request, params ToolParams, hasBody bool, conte
 //  5. Marshal body parameters using appropriate content type handlers
 //  6. Set headers and cookies on the HTTP request
 //  7. Execute the HTTP request with timing
-//  8. Create rich result with metadata for processors
+//  8. Forward chunks of streaming responses (SSE, NDJSON) as progress notifications
+//  9. Poll the status URL of 202 Accepted responses if async polling is configured
+//  10. Create rich result with metadata for processors (XML responses are also provided as JSON)
 //
 // The result includes comprehensive metadata that processors can use for:
 //   - Content formatting (JSON vs text)
@@ -214,53 +478,24 @@ func GetOpenAPIHandler(makeMcpTool *OpenAPIMcpTool, apiClient *APIClient) core.M
 	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
 		startTime := time.Now()
 
-		// Parse parameters using prefix approach
-		params := parsePrefixedParameters(request.GetParameters())
-		method := makeMcpTool.OpenAPIHandlerInput.Method
-
-		// Build URL and body using helper functions
-		fullURL := buildRequestURL(apiClient.BaseURL, makeMcpTool.OpenAPIHandlerInput.Path, params)
-		bodyReader, err := buildRequestBody(params, makeMcpTool)
-		if err != nil {
-			return core.NewBasicExecutionResult("Error: ", fmt.Errorf("failed to build request body: %w", err)), nil
-		}
-
-		// Create the HTTP request
-		req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
-		if err != nil {
-			return core.NewBasicExecutionResult("Error: ", fmt.Errorf("failed to create HTTP request: %w", err)), nil
-		}
-
-		// Apply headers and cookies using helper function
-		setRequestHeaders(req, params, bodyReader != nil, makeMcpTool.OpenAPIHandlerInput.ContentType)
-
-		// Execute request with timing
-		requestStartTime := time.Now()
-		resp, err := apiClient.HTTPClient.Do(req)
-		responseTime := time.Since(requestStartTime)
-
-		if err != nil {
-			return core.NewBasicExecutionResult("Error: ", fmt.Errorf("HTTP request failed: %w", err)), nil
-		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				log.Printf("Failed to close response body: %v", err)
-			}
-		}()
-
-		body, err := io.ReadAll(resp.Body)
+		call, err := apiClient.callOperation(ctx, makeMcpTool, request)
 		if err != nil {
-			return core.NewBasicExecutionResult(
-				"Error: ",
-				fmt.Errorf("failed to read response body: %w", err),
-			), nil
+			return core.NewBasicExecutionResult("Error: ", err), nil
 		}
+		resp, polled := call.response, call.polled
+		method := makeMcpTool.OpenAPIHandlerInput.Method
+		idempotencyHeader, idempotencyKey := call.idempotencyHeader, call.idempotencyKey
 
 		// Format the response for the client (same format as old handler for compatibility)
 		result := fmt.Sprintf(
 			"HTTP %s %s\nStatus: %d\nResponse: %s",
-			req.Method, req.URL, resp.StatusCode, string(body),
+			resp.method, resp.url, resp.statusCode, string(resp.body),
 		)
+		if resp.streamError != nil {
+			result += fmt.Sprintf("\n[response truncated: stream interrupted: %v]", resp.streamError)
+		} else if resp.truncated {
+			result += fmt.Sprintf("\n[response truncated: exceeded maximum size of %d bytes]", apiClient.MaxResponseSize)
+		}
 
 		// Create execution result with rich metadata
 		executionResult := core.NewBasicExecutionResult(result, nil)
@@ -268,14 +503,14 @@ func GetOpenAPIHandler(makeMcpTool *OpenAPIMcpTool, apiClient *APIClient) core.M
 
 		// Set rich metadata for processors using Metadata interface
 		metadata.Set("executionTime", time.Since(startTime))
-		metadata.Set("httpStatus", resp.StatusCode)
-		metadata.Set("responseTime", responseTime)
+		metadata.Set("httpStatus", resp.statusCode)
+		metadata.Set("responseTime", resp.responseTime)
 		metadata.Set("httpMethod", method)
-		metadata.Set("finalURL", req.URL.String())
-		metadata.Set("responseHeaders", resp.Header)
+		metadata.Set("finalURL", resp.url)
+		metadata.Set("responseHeaders", resp.header)
 
 		// Set content type based on response
-		contentType := resp.Header.Get("Content-Type")
+		contentType := resp.header.Get("Content-Type")
 		if contentType != "" {
 			metadata.Set("actualContentType", contentType)
 		}
@@ -284,16 +519,55 @@ func GetOpenAPIHandler(makeMcpTool *OpenAPIMcpTool, apiClient *APIClient) core.M
 		if strings.Contains(contentType, "application/json") {
 			metadata.Set("isJsonData", true)
 			metadata.Set("preferredFormat", "json")
+		} else if isXMLContentType(contentType) && !resp.truncated {
+			// Provide XML responses as JSON so clients can consume them as structured output
+			if structured, err := xmlToJSON(resp.body); err == nil {
+				metadata.Set(core.MetadataStructuredContent, structured)
+				metadata.Set("isJsonData", true)
+				metadata.Set("preferredFormat", "json")
+			}
 		}
 
 		// Mark errors based on HTTP status
-		if resp.StatusCode >= 400 {
+		if resp.statusCode >= 400 {
 			metadata.Set("isErrorResponse", true)
 			metadata.Set("shouldRedact", true) // Error responses might contain sensitive info
 		}
 
+		// Streamed responses were already forwarded to the client chunk by chunk
+		if resp.streamed {
+			metadata.Set("streamed", true)
+			metadata.Set("streamChunks", resp.streamChunks)
+			if resp.streamError != nil {
+				metadata.Set("streamError", resp.streamError.Error())
+			}
+		}
+
+		// Record retries and the idempotency key so operators can correlate requests
+		metadata.Set("attempts", resp.attempts)
+		if idempotencyKey != "" {
+			metadata.Set("idempotencyHeader", idempotencyHeader)
+			metadata.Set("idempotencyKey", idempotencyKey)
+		}
+
+		// Polled operations report the final status response
+		if polled != nil {
+			metadata.Set("asyncPolled", true)
+			metadata.Set("pollAttempts", polled.attempts)
+			metadata.Set("statusURL", polled.statusURL)
+			if polled.state != "" {
+				metadata.Set("asyncState", polled.state)
+			}
+		}
+
+		// Responses cut off by the size limit
+		metadata.Set("truncated", resp.truncated)
+		if resp.truncated && resp.streamError == nil {
+			metadata.Set("maxResponseSize", apiClient.MaxResponseSize)
+		}
+
 		// Large responses should be truncated for display
-		if len(body) > 10000 {
+		if len(resp.body) > 10000 {
 			metadata.Set("shouldTruncate", true)
 			metadata.Set("maxDisplaySize", 10000)
 		}
@@ -0,0 +1,235 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// newTestTool returns a minimal OpenAPIMcpTool for handler tests.
+func newTestTool(method, path string) *OpenAPIMcpTool {
+	input := NewOpenAPIHandlerInput(method, path)
+	return &OpenAPIMcpTool{
+		McpTool:             core.McpTool{Name: "test_tool"},
+		OpenAPIHandlerInput: &input,
+	}
+}
+
+// createToolsFromSpec builds all tools of an inline spec, keyed by tool name.
+func createToolsFromSpec(t *testing.T, spec string) map[string]*OpenAPIMcpTool {
+	t.Helper()
+	return createToolsFromSpecWithAdapter(t, NewLibopenAPIAdapter(), spec)
+}
+
+// createToolsFromSpecWithAdapter builds all tools of an inline spec using the given adapter, keyed by tool name.
+func createToolsFromSpecWithAdapter(t *testing.T, adapter *LibopenAPIAdapter, spec string) map[string]*OpenAPIMcpTool {
+	t.Helper()
+	document, err := libopenapi.NewDocumentWithConfiguration([]byte(spec), datamodel.NewDocumentConfiguration())
+	if err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+	docModel, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		t.Fatalf("Failed to build model: %v", errs[0])
+	}
+
+	tools, err := adapter.CreateToolsFromDocument(docModel)
+	if err != nil {
+		t.Fatalf("Failed to create tools: %v", err)
+	}
+	result := make(map[string]*OpenAPIMcpTool, len(tools))
+	for i := range tools {
+		result[tools[i].Name] = &tools[i]
+	}
+	return result
+}
+
+func TestReadLimited(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		limit         int64
+		wantData      string
+		wantTruncated bool
+	}{
+		{"shorter than limit", "hello", 10, "hello", false},
+		{"exactly limit", "hello", 5, "hello", false},
+		{"longer than limit", "hello world", 5, "hello", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, truncated, err := readLimited(strings.NewReader(tt.input), tt.limit)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if string(data) != tt.wantData {
+				t.Errorf("Expected data %q, got %q", tt.wantData, string(data))
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("Expected truncated=%v, got %v", tt.wantTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestGetOpenAPIHandler_TruncatesLargeResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	apiClient := NewAPIClient(server.URL, 5)
+	apiClient.MaxResponseSize = 10
+	handler := GetOpenAPIHandler(newTestTool("GET", "/large"), apiClient)
+
+	result, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", map[string]any{}, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	content := result.GetContent()
+	if strings.Contains(content, strings.Repeat("x", 11)) {
+		t.Error("Expected response body to be truncated to 10 bytes")
+	}
+	if !strings.Contains(content, "[response truncated") {
+		t.Errorf("Expected truncation marker in content, got: %s", content)
+	}
+	if truncated, _ := result.GetMetadata().Get("truncated"); truncated != true {
+		t.Errorf("Expected truncated metadata to be true, got %v", truncated)
+	}
+}
+
+func TestLoadSpecBytes_RejectsOversizedSpecs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"openapi": "3.0.0", "info": {"title": "Too large", "version": "1.0"}}`))
+	}))
+	defer server.Close()
+
+	adapter := NewLibopenAPIAdapter()
+	adapter.maxSpecSize = 16
+
+	if _, err := adapter.loadSpecBytes(server.URL); err == nil {
+		t.Error("Expected error for spec exceeding the size limit, got nil")
+	}
+}
+
+func TestLoadSpecBytes_YAML(t *testing.T) {
+	fixture, err := os.ReadFile("../../../testbed/openapi/sample_specifications/simplewithbody.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		switch r.URL.Path {
+		case "/openapi":
+			w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+			_, _ = w.Write(fixture)
+		case "/openapi.yml":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write(fixture)
+		case "/invalid":
+			w.Header().Set("Content-Type", "application/x-yaml")
+			_, _ = w.Write([]byte("openapi: 3.0.0\ninfo:\n\ttitle: tabs are not allowed\n"))
+		case "/docs":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		location    string
+		expectError string
+	}{
+		{"YAML file", "../../../testbed/openapi/sample_specifications/simplewithbody.yaml", ""},
+		{"YAML content type", server.URL + "/openapi", ""},
+		{"YAML URL extension", server.URL + "/openapi.yml", ""},
+		{"Invalid YAML", server.URL + "/invalid", "invalid YAML in OpenAPI spec"},
+		{"HTML page", server.URL + "/docs", "got an HTML page"},
+		{"Not found", server.URL + "/missing.yaml", "HTTP 404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specBytes, err := NewLibopenAPIAdapter().loadSpecBytes(tt.location)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if string(specBytes) != string(fixture) {
+				t.Error("Expected the YAML spec to be returned unchanged")
+			}
+		})
+	}
+
+	if !strings.Contains(accept, "application/yaml") {
+		t.Errorf("Expected YAML to be accepted, got Accept header %q", accept)
+	}
+}
+
+func TestGetOpenAPIHandler_AddsStaticParams(t *testing.T) {
+	var token, cookie, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Token")
+		cookie = r.Header.Get("Cookie")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	tool := newTestTool("POST", "/users")
+	tool.OpenAPIHandlerInput.Headers["X-Token"] = "configured"
+	tool.OpenAPIHandlerInput.Cookies["session"] = "abc"
+	tool.OpenAPIHandlerInput.BodyAppend["source"] = "mcp"
+	handler := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))
+
+	params := map[string]any{"header__X-Token": "model", "body__name": "Jane"}
+	if _, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", params, "")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if token != "configured" || cookie != "session=abc" {
+		t.Errorf("Expected configured header and cookie, got %q and %q", token, cookie)
+	}
+	if !strings.Contains(body, `"source":"mcp"`) || !strings.Contains(body, `"name":"Jane"`) {
+		t.Errorf("Expected body fields to be appended, got %s", body)
+	}
+
+	if _, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", map[string]any{}, "")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if body != "" {
+		t.Errorf("Expected no body for requests without body parameters, got %s", body)
+	}
+}
@@ -80,5 +80,6 @@ func (params *ToolParams) AttachToolParams(paramList []map[string]any) {
 type ToolInputProperty struct {
 	Type        string            `json:"type"`
 	Description string            `json:"description,omitempty"`
-	Location    ParameterLocation `json:"location"` // OpenAPI 'in' value: path, query, header, cookie, body, etc.
+	Location    ParameterLocation `json:"location"`       // OpenAPI 'in' value: path, query, header, cookie, body, etc.
+	Enum        []any             `json:"enum,omitempty"` // Allowed values declared by the schema
 }
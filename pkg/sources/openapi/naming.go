@@ -0,0 +1,208 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"text/template"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Tool naming strategies
+const (
+	ToolNamingOperationID = "operationId"  // operationId, falling back to method_path (default)
+	ToolNamingMethodPath  = "method_path"  // HTTP method and path, e.g. get_users_id
+	ToolNamingTagPrefixed = "tag_prefixed" // First tag followed by the operationId name
+	ToolNamingTemplate    = "template"     // Custom Go template, see ToolNameData
+)
+
+// MaxToolNameLength is the maximum length of generated tool names.
+const MaxToolNameLength = 64
+
+// toolNameHashLength is the number of hex characters of the hash suffix of shortened or duplicate names.
+const toolNameHashLength = 8
+
+// ToolNameData is available to custom tool name templates, e.g. "{{.Tag}}_{{.OperationID}}".
+type ToolNameData struct {
+	OperationID string
+	Method      string
+	Path        string
+	Tag         string // First tag of the operation, empty if untagged
+	Tags        []string
+	Summary     string
+}
+
+// toolNamer generates tool names following a naming strategy.
+type toolNamer struct {
+	strategy string
+	template *template.Template
+}
+
+// newToolNamer creates a toolNamer for the given strategy; nameTemplate is required for ToolNamingTemplate.
+func newToolNamer(strategy, nameTemplate string) (*toolNamer, error) {
+	namer := &toolNamer{strategy: strategy}
+	switch strategy {
+	case "":
+		namer.strategy = ToolNamingOperationID
+	case ToolNamingOperationID, ToolNamingMethodPath, ToolNamingTagPrefixed:
+	case ToolNamingTemplate:
+		if nameTemplate == "" {
+			return nil, fmt.Errorf("tool naming strategy %s requires a tool name template", ToolNamingTemplate)
+		}
+		parsed, err := template.New("toolName").Option("missingkey=error").Parse(nameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool name template: %w", err)
+		}
+		namer.template = parsed
+	default:
+		return nil, fmt.Errorf("unknown tool naming strategy %q, expected one of %s, %s, %s, %s",
+			strategy, ToolNamingOperationID, ToolNamingMethodPath, ToolNamingTagPrefixed, ToolNamingTemplate)
+	}
+	return namer, nil
+}
+
+// name returns the sanitized tool name of an operation.
+func (n *toolNamer) name(method, path string, operation *v3.Operation) string {
+	data := ToolNameData{
+		OperationID: operation.OperationId,
+		Method:      method,
+		Path:        path,
+		Tags:        operation.Tags,
+		Summary:     operation.Summary,
+	}
+	if len(operation.Tags) > 0 {
+		data.Tag = operation.Tags[0]
+	}
+
+	methodPath := fmt.Sprintf("%s_%s", method, path)
+	name := data.OperationID
+	if name == "" {
+		name = methodPath
+	}
+
+	switch n.strategy {
+	case ToolNamingMethodPath:
+		name = methodPath
+	case ToolNamingTagPrefixed:
+		if data.Tag != "" {
+			name = data.Tag + "_" + name
+		}
+	case ToolNamingTemplate:
+		var rendered strings.Builder
+		if err := n.template.Execute(&rendered, data); err != nil {
+			log.Printf("Warning: tool name template failed for %s %s, using default name: %v", strings.ToUpper(method), path, err)
+		} else if strings.TrimSpace(rendered.String()) != "" {
+			name = rendered.String()
+		}
+	}
+	return sanitizeToolName(name)
+}
+
+// sanitizeToolName lowercases a name, replaces characters outside [a-z0-9_] and
+// shortens names exceeding MaxToolNameLength using a stable hash suffix.
+func sanitizeToolName(name string) string {
+	// Path parameter braces are dropped so "/users/{id}" becomes "_users_id"
+	name = strings.NewReplacer("{", "", "}", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+
+	var sanitized strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			sanitized.WriteRune(r)
+		} else {
+			sanitized.WriteRune('_')
+		}
+	}
+	result := sanitized.String()
+	if result == "" {
+		result = "tool"
+	}
+
+	if len(result) > MaxToolNameLength {
+		result = result[:MaxToolNameLength-toolNameHashLength-1] + "_" + toolNameHash(name)
+	}
+	return result
+}
+
+// toolNameHash returns a short stable hash of value.
+func toolNameHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:toolNameHashLength]
+}
+
+// toolNameSet de-duplicates tool names of a document in iteration order.
+type toolNameSet struct {
+	owners     map[string]string   // Tool name to the operation ("METHOD /path") that owns it
+	collisions map[string][]string // Tool name to all operations that requested it
+}
+
+func newToolNameSet() *toolNameSet {
+	return &toolNameSet{
+		owners:     make(map[string]string),
+		collisions: make(map[string][]string),
+	}
+}
+
+// claim returns name if unused, otherwise name with a hash suffix derived from method and path.
+// The suffix only depends on the operation, so names stay stable when other operations change.
+func (s *toolNameSet) claim(name, method, path string) string {
+	operation := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	owner, exists := s.owners[name]
+	if !exists {
+		s.owners[name] = operation
+		return name
+	}
+
+	if len(s.collisions[name]) == 0 {
+		s.collisions[name] = []string{owner}
+	}
+	s.collisions[name] = append(s.collisions[name], operation)
+
+	base := name
+	if len(base) > MaxToolNameLength-toolNameHashLength-1 {
+		base = base[:MaxToolNameLength-toolNameHashLength-1]
+	}
+	unique := base + "_" + toolNameHash(operation)
+	for attempt := 2; ; attempt++ {
+		if _, taken := s.owners[unique]; !taken {
+			break
+		}
+		unique = base + "_" + toolNameHash(fmt.Sprintf("%s#%d", operation, attempt))
+	}
+	s.owners[unique] = operation
+	return unique
+}
+
+// warnCollisions logs all tool names that were requested by more than one operation.
+func (s *toolNameSet) warnCollisions() {
+	if len(s.collisions) == 0 {
+		return
+	}
+	names := make([]string, 0, len(s.collisions))
+	for name := range s.collisions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	log.Printf("Warning: %d tool name collision(s) resolved with hash suffixes:", len(names))
+	for _, name := range names {
+		log.Printf("  - %s: %s", name, strings.Join(s.collisions[name], ", "))
+	}
+}
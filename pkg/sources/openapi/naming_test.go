@@ -0,0 +1,160 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+func TestSanitizeToolName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lowercases", "getUserById", "getuserbyid"},
+		{"path parameters", "get_/users/{id}", "get__users_id"},
+		{"invalid characters", "list users:v2.0", "list_users_v2_0"},
+		{"hyphens", "create-user", "create_user"},
+		{"empty", "  ", "tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeToolName(tt.input); got != tt.expected {
+				t.Errorf("sanitizeToolName(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeToolName_MaxLength(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	got := sanitizeToolName(long)
+	if len(got) != MaxToolNameLength {
+		t.Errorf("Expected name of length %d, got %d: %s", MaxToolNameLength, len(got), got)
+	}
+	if got != sanitizeToolName(long) {
+		t.Error("Expected shortened names to be stable")
+	}
+	if got == sanitizeToolName(long+"b") {
+		t.Error("Expected different long names to keep different hash suffixes")
+	}
+}
+
+func TestToolNamer_Strategies(t *testing.T) {
+	operation := &v3.Operation{OperationId: "getUser", Tags: []string{"Users"}, Summary: "Get a user"}
+	untagged := &v3.Operation{}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		template  string
+		operation *v3.Operation
+		expected  string
+	}{
+		{"default", "", "", operation, "getuser"},
+		{"operationId", ToolNamingOperationID, "", operation, "getuser"},
+		{"operationId falls back to method_path", ToolNamingOperationID, "", untagged, "get__users_id"},
+		{"method_path", ToolNamingMethodPath, "", operation, "get__users_id"},
+		{"tag_prefixed", ToolNamingTagPrefixed, "", operation, "users_getuser"},
+		{"tag_prefixed without tags", ToolNamingTagPrefixed, "", untagged, "get__users_id"},
+		{"template", ToolNamingTemplate, "{{.Tag}}.{{.Method}}.{{.OperationID}}", operation, "users_get_getuser"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namer, err := newToolNamer(tt.strategy, tt.template)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := namer.name("get", "/users/{id}", tt.operation); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewToolNamer_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		template string
+	}{
+		{"unknown strategy", "random", ""},
+		{"template strategy without template", ToolNamingTemplate, ""},
+		{"invalid template", ToolNamingTemplate, "{{.OperationID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newToolNamer(tt.strategy, tt.template); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestCreateToolsFromDocument_DeduplicatesNames(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Duplicate API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: Success
+  /v2/users:
+    get:
+      operationId: LISTUSERS
+      responses:
+        '200':
+          description: Success
+  /v3/users:
+    get:
+      operationId: ListUsers
+      responses:
+        '200':
+          description: Success
+`
+	first := createToolsFromSpec(t, spec)
+	if len(first) != 3 {
+		t.Fatalf("Expected 3 uniquely named tools, got %d: %v", len(first), first)
+	}
+	if tool := first["listusers"]; tool == nil || tool.OpenAPIHandlerInput.Path != "/users" {
+		t.Errorf("Expected the first operation to keep its name, got %v", tool)
+	}
+	for name, tool := range first {
+		if name == "listusers" {
+			continue
+		}
+		expected := "listusers_" + toolNameHash("GET "+tool.OpenAPIHandlerInput.Path)
+		if name != expected {
+			t.Errorf("Expected duplicate to be named %s, got %s", expected, name)
+		}
+	}
+
+	// De-duplication must be deterministic
+	second := createToolsFromSpec(t, spec)
+	for name := range first {
+		if second[name] == nil {
+			t.Errorf("Expected tool %s to be generated again", name)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package openapi
 
 import (
+	"context"
+	"strings"
+
 	core "github.com/T4cceptor/MakeMCP/pkg/core"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
@@ -10,8 +13,12 @@ import (
 type OpenAPIMcpTool struct {
 	core.McpTool
 	OpenAPIHandlerInput *OpenAPIHandlerInput    `json:"oapiHandlerInput,omitempty"`
+	Tags                []string                `json:"tags,omitempty"` // Tags of the operation, used to search and select tools
 	handler             core.MakeMcpToolHandler `json:"-"`
 	Operation           *v3.Operation           `json:"-"`
+	resource            *toolResource           // Set if the tool is also exposed as resource
+	resourceHandler     core.MakeMcpToolHandler
+	completer           *completer
 }
 
 // GetName returns the name of the OpenAPI MCP tool.
@@ -29,6 +36,45 @@ func (o *OpenAPIMcpTool) GetHandler() core.MakeMcpToolHandler {
 	return o.handler
 }
 
+// GetTags returns the tags of the tool's operation.
+func (o *OpenAPIMcpTool) GetTags() []string {
+	return o.Tags
+}
+
+// GetEndpoint returns the method and path of the tool's operation, composite tools have none.
+func (o *OpenAPIMcpTool) GetEndpoint() string {
+	if o.OpenAPIHandlerInput == nil || o.OpenAPIHandlerInput.Path == "" {
+		return ""
+	}
+	return strings.ToUpper(o.OpenAPIHandlerInput.Method) + " " + o.OpenAPIHandlerInput.Path
+}
+
+// ToMcpResource returns the resource the tool is exposed as, if any.
+func (o *OpenAPIMcpTool) ToMcpResource() (core.McpResource, bool) {
+	if o.resource == nil {
+		return core.McpResource{}, false
+	}
+	return o.resource.resource, true
+}
+
+// GetResourceHandler returns the transport-agnostic handler reading the tool's resource.
+func (o *OpenAPIMcpTool) GetResourceHandler() core.MakeMcpToolHandler {
+	return o.resourceHandler
+}
+
+// CompleteArgument returns the known values of a parameter or resource template variable starting with value.
+func (o *OpenAPIMcpTool) CompleteArgument(ctx context.Context, argument, value string) ([]string, error) {
+	if o.completer == nil {
+		return nil, nil
+	}
+	if o.resource != nil {
+		if param, ok := o.resource.variables[argument]; ok {
+			argument = param
+		}
+	}
+	return o.completer.complete(ctx, o, argument, value)
+}
+
 // OpenAPIHandlerInput defines how a particular endpoint is to be called
 type OpenAPIHandlerInput struct {
 	Method      string            `json:"method"`
@@ -37,6 +83,14 @@ type OpenAPIHandlerInput struct {
 	Cookies     map[string]string `json:"cookies"`
 	BodyAppend  map[string]any    `json:"bodyAppend"`
 	ContentType string            `json:"contentType,omitempty"`
+
+	Async             *AsyncPollingConfig `json:"async,omitempty"`             // Polling of 202 Accepted responses, disabled if nil
+	IdempotencyHeader string              `json:"idempotencyHeader,omitempty"` // Header carrying a generated idempotency key per tool call
+	FileFields        []string            `json:"fileFields,omitempty"`        // Multipart fields that are file uploads
+	XMLSchema         *XMLNode            `json:"xmlSchema,omitempty"`         // XML hints of structured XML request bodies
+	Accept            string              `json:"accept,omitempty"`            // Accept header derived from the declared response media types
+	FixedParams       map[string]any      `json:"fixedParams,omitempty"`       // Prefixed parameters always sent with these values
+	Workflow          *Workflow           `json:"workflow,omitempty"`          // Steps of composite tools, which have no method and path
 }
 
 // NewOpenAPIHandlerInput creates a new OpenAPIHandlerInput
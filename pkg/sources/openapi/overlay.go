@@ -0,0 +1,76 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/speakeasy-api/jsonpath/pkg/overlay"
+	"gopkg.in/yaml.v3"
+)
+
+// applyOverlays applies OpenAPI Overlay 1.0 documents to the spec bytes in the given order.
+// Overlays are loaded like specs, either from a file or a URL.
+func (a *LibopenAPIAdapter) applyOverlays(specBytes []byte, overlayLocations []string) ([]byte, error) {
+	if len(overlayLocations) == 0 {
+		return specBytes, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(specBytes, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec for overlays: %w", err)
+	}
+
+	for _, location := range overlayLocations {
+		log.Println("Applying OpenAPI overlay from:", location)
+		overlayDoc, err := a.loadOverlay(location)
+		if err != nil {
+			return nil, err
+		}
+		if err := overlayDoc.ApplyTo(&root); err != nil {
+			return nil, fmt.Errorf("failed to apply overlay %s: %w", location, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec with overlays: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec with overlays: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// loadOverlay loads and validates an overlay document.
+func (a *LibopenAPIAdapter) loadOverlay(location string) (*overlay.Overlay, error) {
+	overlayBytes, err := a.loadSpecBytes(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load overlay %s: %w", location, err)
+	}
+
+	var overlayDoc overlay.Overlay
+	if err := yaml.Unmarshal(overlayBytes, &overlayDoc); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay %s: %w", location, err)
+	}
+	if err := overlayDoc.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid overlay %s: %w", location, err)
+	}
+	return &overlayDoc, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const overlayBaseSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Vendor API", "version": "1.0.0"},
+  "paths": {
+    "/accounts": {
+      "get": {"operationId": "listAccounts", "description": "Vendor description", "responses": {"200": {"description": "ok"}}},
+      "delete": {"operationId": "deleteAccounts", "responses": {"204": {"description": "deleted"}}}
+    }
+  }
+}`
+
+const overlayDocument = `overlay: 1.0.0
+info:
+  title: Customize vendor API
+  version: 1.0.0
+actions:
+  - target: $.paths['/accounts'].get
+    update:
+      description: Lists all accounts of the current user.
+      x-mcp-name: accounts_list
+  - target: $.paths['/accounts'].delete
+    remove: true
+`
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadOpenAPISpec_AppliesOverlays(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeTestFile(t, dir, "spec.json", overlayBaseSpec)
+	overlayPath := writeTestFile(t, dir, "overlay.yaml", overlayDocument)
+
+	adapter := NewLibopenAPIAdapter()
+	adapter.overlays = []string{overlayPath}
+	doc, err := adapter.LoadOpenAPISpec(specPath, false)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	tools, err := adapter.CreateToolsFromDocument(doc)
+	if err != nil {
+		t.Fatalf("Failed to create tools: %v", err)
+	}
+	if len(tools) != 1 {
+		t.Fatalf("Expected the removed operation to be gone, got %d tools", len(tools))
+	}
+	if tools[0].Name != "accounts_list" {
+		t.Errorf("Expected tool renamed by overlay, got %s", tools[0].Name)
+	}
+	if !strings.HasPrefix(tools[0].Description, "Lists all accounts of the current user.") {
+		t.Errorf("Expected description from overlay, got %q", tools[0].Description)
+	}
+}
+
+func TestLoadOpenAPISpec_InvalidOverlay(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeTestFile(t, dir, "spec.json", overlayBaseSpec)
+
+	tests := []struct {
+		name    string
+		overlay string
+	}{
+		{"wrong version", strings.Replace(overlayDocument, "overlay: 1.0.0", "overlay: 2.0.0", 1)},
+		{"invalid JSONPath", strings.Replace(overlayDocument, "$.paths['/accounts'].get", "$.paths[", 1)},
+		{"not YAML", "overlay: [unclosed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := NewLibopenAPIAdapter()
+			adapter.overlays = []string{writeTestFile(t, dir, "overlay.yaml", tt.overlay)}
+			if _, err := adapter.LoadOpenAPISpec(specPath, false); err == nil {
+				t.Error("Expected error for invalid overlay")
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		adapter := NewLibopenAPIAdapter()
+		adapter.overlays = []string{filepath.Join(dir, "missing.yaml")}
+		if _, err := adapter.LoadOpenAPISpec(specPath, false); err == nil {
+			t.Error("Expected error for missing overlay")
+		}
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
 
 	core "github.com/T4cceptor/MakeMCP/pkg/core"
@@ -33,6 +34,28 @@ type OpenAPIParams struct {
 	BaseURL        string `json:"baseURL"`        // Base URL of the API for tool calls
 	Timeout        int    `json:"timeout"`        // HTTP timeout in seconds
 	StrictValidate bool   `json:"strictValidate"` // Enable strict OpenAPI validation
+
+	MaxResponseSize int64  `json:"maxResponseSize,omitempty"` // Maximum bytes read from API responses and remote specs
+	MaxRetries      int    `json:"maxRetries,omitempty"`      // Retries for transient failures of idempotent requests
+	FileUploadDir   string `json:"fileUploadDir,omitempty"`   // Directory local files may be uploaded from, disabled if empty
+
+	ContentTypePreferences []string          `json:"contentTypePreferences,omitempty"` // Request content types in order of preference
+	ContentTypeOverrides   map[string]string `json:"contentTypeOverrides,omitempty"`   // Request content type per tool name
+
+	ToolNaming       string `json:"toolNaming,omitempty"`       // Tool naming strategy, defaults to operationId
+	ToolNameTemplate string `json:"toolNameTemplate,omitempty"` // Go template for the template naming strategy
+
+	Overlays []string `json:"overlays,omitempty"` // OpenAPI Overlay documents applied to the spec in order
+	Arazzo   string   `json:"arazzo,omitempty"`   // Arazzo document whose workflows become composite tools
+
+	Resources bool `json:"resources,omitempty"` // Expose GET operations as MCP resources and resource templates
+	Prompts   bool `json:"prompts,omitempty"`   // Generate prompts from tags and operation examples
+
+	CompletionLookups map[string]CompletionLookup `json:"completionLookups,omitempty"` // Operations listing the values of a parameter, by parameter name
+
+	Headers      map[string]string      `json:"headers,omitempty"`      // Headers sent with every API request
+	Credentials  *Credentials           `json:"credentials,omitempty"`  // Credentials authenticating API requests
+	Environments map[string]Environment `json:"environments,omitempty"` // Named environments overriding the API parameters, selected when loading
 }
 
 // NewOpenAPIParams creates a new OpenAPIParams with default values.
@@ -43,7 +66,17 @@ func NewOpenAPIParams(sharedParams *core.BaseAppParams) *OpenAPIParams {
 		BaseURL:        "",
 		Timeout:        30, // default 30 seconds
 		StrictValidate: false,
+
+		MaxResponseSize: DefaultMaxResponseSize,
+	}
+}
+
+// GetMaxResponseSize returns the configured response size limit, or the default if unset.
+func (p *OpenAPIParams) GetMaxResponseSize() int64 {
+	if p.MaxResponseSize <= 0 {
+		return DefaultMaxResponseSize
 	}
+	return p.MaxResponseSize
 }
 
 // GetSharedParams returns the shared parameters.
@@ -51,6 +84,16 @@ func (p *OpenAPIParams) GetSharedParams() *core.BaseAppParams {
 	return p.BaseAppParams
 }
 
+// GetWatchLocations returns the spec, overlay and Arazzo documents the tools are created from.
+func (p *OpenAPIParams) GetWatchLocations() []string {
+	locations := []string{p.Specs}
+	locations = append(locations, p.Overlays...)
+	if p.Arazzo != "" {
+		locations = append(locations, p.Arazzo)
+	}
+	return locations
+}
+
 // GetSourceType returns the source type identifier.
 func (p *OpenAPIParams) GetSourceType() string {
 	return "openapi"
@@ -63,10 +106,6 @@ func (p *OpenAPIParams) Validate() error {
 		return errors.New("specs parameter is required - must specify OpenAPI specification URL or file path")
 	}
 
-	if p.BaseURL == "" {
-		return errors.New("base-url parameter is required - must specify the API base URL for tool execution")
-	}
-
 	// Validate URLs if they look like URLs (contain protocol)
 	if strings.Contains(p.Specs, "://") {
 		if _, err := url.Parse(p.Specs); err != nil {
@@ -74,20 +113,107 @@ func (p *OpenAPIParams) Validate() error {
 		}
 	}
 
-	if strings.Contains(p.BaseURL, "://") {
-		if _, err := url.Parse(p.BaseURL); err != nil {
-			return fmt.Errorf("invalid base URL: %w", err)
-		}
+	if err := validateBaseURL(p.BaseURL); err != nil {
+		return err
 	}
 
 	// Validate timeout
 	if p.Timeout <= 0 {
-		return errors.New("timeout must be greater than 0")
+		return errInvalidTimeout
+	}
+
+	// Validate response size limit (0 falls back to the default)
+	if p.MaxResponseSize < 0 {
+		return errNegativeMaxResponseSize
+	}
+
+	if p.MaxRetries < 0 {
+		return errNegativeMaxRetries
+	}
+
+	// Validate credentials of the default and all named environments
+	if p.Credentials != nil {
+		if err := p.Credentials.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, environment := range p.Environments {
+		if environment.Timeout < 0 {
+			return fmt.Errorf("timeout of environment %s must not be negative", name)
+		}
+		if environment.Credentials != nil {
+			if err := environment.Credentials.Validate(); err != nil {
+				return fmt.Errorf("environment %s: %w", name, err)
+			}
+		}
+	}
+
+	// Validate file upload directory if local file uploads are enabled
+	if err := validateFileUploadDir(p.FileUploadDir); err != nil {
+		return err
+	}
+
+	// Validate overlay locations, remote overlays are checked when loading
+	for _, overlay := range p.Overlays {
+		if strings.Contains(overlay, "://") {
+			if _, err := url.Parse(overlay); err != nil {
+				return fmt.Errorf("invalid overlay URL: %w", err)
+			}
+		} else if _, err := os.Stat(overlay); err != nil {
+			return fmt.Errorf("invalid overlay file: %w", err)
+		}
+	}
+
+	// Validate Arazzo document location, remote documents are checked when loading
+	if p.Arazzo != "" && !strings.Contains(p.Arazzo, "://") {
+		if _, err := os.Stat(p.Arazzo); err != nil {
+			return fmt.Errorf("invalid Arazzo file: %w", err)
+		}
+	}
+
+	// Validate tool naming strategy and template
+	if _, err := newToolNamer(p.ToolNaming, p.ToolNameTemplate); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// Errors of invalid numeric params.
+var (
+	errInvalidTimeout          = errors.New("timeout must be greater than 0")
+	errNegativeMaxResponseSize = errors.New("max-response-size must not be negative")
+	errNegativeMaxRetries      = errors.New("max-retries must not be negative")
+)
+
+// validateBaseURL checks that the API base URL is set and can be parsed if it looks like a URL.
+func validateBaseURL(baseURL string) error {
+	if baseURL == "" {
+		return errors.New("base-url parameter is required - must specify the API base URL for tool execution")
+	}
+	if strings.Contains(baseURL, "://") {
+		if _, err := url.Parse(baseURL); err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateFileUploadDir checks that the file upload directory exists, if local file uploads are enabled.
+func validateFileUploadDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("invalid file upload directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("file upload directory %s is not a directory", dir)
+	}
+	return nil
+}
+
 // ToJSON returns a JSON representation for logging and debugging.
 func (p *OpenAPIParams) ToJSON() string {
 	jsonBytes, err := json.Marshal(p)
@@ -97,6 +223,53 @@ func (p *OpenAPIParams) ToJSON() string {
 	return string(jsonBytes)
 }
 
+// ApplyOverrides applies flag values of GetOverrideFlags to params loaded from a config file.
+// Only the overridden values are validated, as the file locations of a loaded config,
+// e.g. of overlays, need not be valid relative to the working directory.
+func (s *OpenAPISource) ApplyOverrides(params core.AppParams, flags map[string]any) error {
+	openAPIParams, ok := params.(*OpenAPIParams)
+	if !ok {
+		return fmt.Errorf("expected OpenAPIParams, got %T", params)
+	}
+	for name, value := range flags {
+		var err error
+		switch name {
+		case "base-url":
+			openAPIParams.BaseURL, ok = value.(string)
+			err = validateBaseURL(openAPIParams.BaseURL)
+		case "timeout":
+			openAPIParams.Timeout, ok = value.(int)
+			if openAPIParams.Timeout <= 0 {
+				err = errInvalidTimeout
+			}
+		case "max-response-size":
+			var size int
+			size, ok = value.(int)
+			openAPIParams.MaxResponseSize = int64(size)
+			if size < 0 {
+				err = errNegativeMaxResponseSize
+			}
+		case "max-retries":
+			openAPIParams.MaxRetries, ok = value.(int)
+			if openAPIParams.MaxRetries < 0 {
+				err = errNegativeMaxRetries
+			}
+		case "file-upload-dir":
+			openAPIParams.FileUploadDir, ok = value.(string)
+			err = validateFileUploadDir(openAPIParams.FileUploadDir)
+		default:
+			return fmt.Errorf("%s cannot be overridden", name)
+		}
+		if !ok {
+			return fmt.Errorf("invalid value for %s: %v", name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ParseFromCLIInput creates OpenAPIParams from raw CLI input with type safety.
 func ParseFromCLIInput(input *core.CLIParamsInput) (*OpenAPIParams, error) {
 	params := NewOpenAPIParams(input.SharedParams)
@@ -135,6 +308,83 @@ func ParseFromCLIInput(input *core.CLIParamsInput) (*OpenAPIParams, error) {
 		params.StrictValidate = strict
 	}
 
+	// Extract optional max-response-size parameter
+	if maxSize, ok := input.CliFlags["max-response-size"].(int); ok && maxSize != 0 {
+		params.MaxResponseSize = int64(maxSize)
+	}
+
+	// Extract optional max-retries parameter
+	if maxRetries, ok := input.CliFlags["max-retries"].(int); ok {
+		params.MaxRetries = maxRetries
+	}
+
+	// Extract optional file-upload-dir parameter
+	if uploadDir, ok := input.CliFlags["file-upload-dir"].(string); ok {
+		params.FileUploadDir = uploadDir
+	}
+
+	// Extract optional content-types parameter
+	if contentTypes, ok := input.CliFlags["content-types"].([]string); ok && len(contentTypes) > 0 {
+		params.ContentTypePreferences = contentTypes
+	}
+
+	// Extract optional content-type-override parameters, given as tool=content-type
+	if overrides, ok := input.CliFlags["content-type-override"].([]string); ok && len(overrides) > 0 {
+		params.ContentTypeOverrides = make(map[string]string, len(overrides))
+		for _, override := range overrides {
+			toolName, contentType, found := strings.Cut(override, "=")
+			if !found || toolName == "" || contentType == "" {
+				return nil, fmt.Errorf("content-type-override must have the form tool=content-type, got: %s", override)
+			}
+			params.ContentTypeOverrides[toolName] = contentType
+		}
+	}
+
+	// Extract optional tool naming parameters
+	if toolNaming, ok := input.CliFlags["tool-naming"].(string); ok {
+		params.ToolNaming = toolNaming
+	}
+	if nameTemplate, ok := input.CliFlags["tool-name-template"].(string); ok && nameTemplate != "" {
+		params.ToolNameTemplate = nameTemplate
+		if params.ToolNaming == "" || params.ToolNaming == ToolNamingOperationID {
+			// A template implies the template strategy unless another strategy was chosen explicitly
+			params.ToolNaming = ToolNamingTemplate
+		}
+	}
+
+	// Extract optional overlay parameters
+	if overlays, ok := input.CliFlags["overlay"].([]string); ok && len(overlays) > 0 {
+		params.Overlays = overlays
+	}
+
+	// Extract optional arazzo parameter
+	if arazzo, ok := input.CliFlags["arazzo"].(string); ok {
+		params.Arazzo = arazzo
+	}
+
+	// Extract optional resources parameter
+	if resources, ok := input.CliFlags["resources"].(bool); ok {
+		params.Resources = resources
+	}
+
+	// Extract optional prompts parameter
+	if prompts, ok := input.CliFlags["prompts"].(bool); ok {
+		params.Prompts = prompts
+	}
+
+	// Extract optional completion-lookup parameters, given as param=tool[:path]
+	if lookups, ok := input.CliFlags["completion-lookup"].([]string); ok && len(lookups) > 0 {
+		params.CompletionLookups = make(map[string]CompletionLookup, len(lookups))
+		for _, lookup := range lookups {
+			param, target, found := strings.Cut(lookup, "=")
+			toolName, path, _ := strings.Cut(target, ":")
+			if !found || param == "" || toolName == "" {
+				return nil, fmt.Errorf("completion-lookup must have the form param=tool[:path], got: %s", lookup)
+			}
+			params.CompletionLookups[param] = CompletionLookup{Tool: toolName, Path: path}
+		}
+	}
+
 	// Validate the constructed parameters
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("parameter validation failed: %w", err)
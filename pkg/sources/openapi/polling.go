@@ -0,0 +1,251 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// Default values for polling long-running operations.
+const (
+	defaultPollInitialInterval = 1000 // milliseconds
+	defaultPollMaxInterval     = 30000
+	defaultPollTimeout         = 300 // seconds
+)
+
+// defaultTerminalStates are operation states that end polling if none are configured.
+var defaultTerminalStates = []string{
+	"succeeded", "success", "successful", "completed", "complete", "done", "finished",
+	"failed", "failure", "error", "cancelled", "canceled",
+}
+
+// AsyncPollingConfig defines how a long-running operation answering with 202 Accepted is polled.
+// It can be set per tool in the config file or via the x-mcp-async extension of an operation.
+type AsyncPollingConfig struct {
+	StatusURLField  string   `json:"statusUrlField,omitempty"`  // Body field of the 202 response holding the status URL, Location header is used if empty
+	StatusField     string   `json:"statusField,omitempty"`     // Body field of the status response holding the operation state
+	TerminalStates  []string `json:"terminalStates,omitempty"`  // States that end polling, case-insensitive
+	InitialInterval int      `json:"initialInterval,omitempty"` // Initial polling interval in milliseconds
+	MaxInterval     int      `json:"maxInterval,omitempty"`     // Maximum polling interval in milliseconds
+	Timeout         int      `json:"timeout,omitempty"`         // Maximum total polling time in seconds
+}
+
+// withDefaults returns a copy of the config with all unset values replaced by defaults.
+func (c AsyncPollingConfig) withDefaults() AsyncPollingConfig {
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = defaultPollInitialInterval
+	}
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = defaultPollMaxInterval
+	}
+	if c.MaxInterval < c.InitialInterval {
+		c.MaxInterval = c.InitialInterval
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultPollTimeout
+	}
+	if len(c.TerminalStates) == 0 {
+		c.TerminalStates = defaultTerminalStates
+	}
+	return c
+}
+
+// isTerminalState returns true if state is one of the configured terminal states.
+func (c AsyncPollingConfig) isTerminalState(state string) bool {
+	for _, terminal := range c.TerminalStates {
+		if strings.EqualFold(terminal, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// pollResult holds the final response of a polled operation.
+type pollResult struct {
+	response  *apiResponse
+	statusURL string
+	attempts  int
+	state     string
+}
+
+// pollAsyncOperation follows the status URL of a 202 Accepted response with exponential backoff
+// until the operation reaches a terminal state, the timeout expires or the context is cancelled.
+// Progress is reported to the notifier after every poll.
+func pollAsyncOperation(
+	ctx context.Context,
+	apiClient *APIClient,
+	original *http.Request,
+	accepted *apiResponse,
+	config AsyncPollingConfig,
+	notifier core.ProgressNotifier,
+) (*pollResult, error) {
+	config = config.withDefaults()
+
+	statusURL, err := resolveStatusURL(original.URL, accepted, config.StatusURLField)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.Timeout)*time.Second)
+	defer cancel()
+
+	result := &pollResult{response: accepted, statusURL: statusURL}
+	interval := time.Duration(config.InitialInterval) * time.Millisecond
+	maxInterval := time.Duration(config.MaxInterval) * time.Millisecond
+	wait := retryAfter(accepted.header, interval, maxInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("async operation did not complete after %d polls: %w", result.attempts, ctx.Err())
+		case <-time.After(wait):
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, result.statusURL, nil)
+		if err != nil {
+			return result, fmt.Errorf("failed to create status request: %w", err)
+		}
+		// Reuse authentication headers and cookies of the original request
+		req.Header = original.Header.Clone()
+		req.Header.Del("Content-Type")
+
+		resp, err := apiClient.execute(ctx, req, "", core.NoopProgressNotifier{})
+		if err != nil {
+			return result, fmt.Errorf("failed to poll status URL: %w", err)
+		}
+		result.response = resp
+		result.attempts++
+
+		terminal := false
+		if config.StatusField != "" {
+			result.state = extractStateField(resp.body, config.StatusField)
+			terminal = config.isTerminalState(result.state) || resp.statusCode >= 400
+		} else {
+			// Without a status field the operation is done once the API stops answering with 202
+			terminal = resp.statusCode != http.StatusAccepted
+		}
+
+		message := fmt.Sprintf("Polled %s: HTTP %d", result.statusURL, resp.statusCode)
+		if result.state != "" {
+			message += fmt.Sprintf(", state %s", result.state)
+		}
+		if err := notifier.NotifyProgress(float64(result.attempts), 0, message); err != nil {
+			log.Printf("Failed to report polling progress: %v", err)
+		}
+
+		if terminal {
+			return result, nil
+		}
+
+		// Follow updated status locations
+		if resp.statusCode == http.StatusAccepted {
+			if next, err := resolveStatusURL(req.URL, resp, config.StatusURLField); err == nil {
+				result.statusURL = next
+			}
+		}
+
+		wait = retryAfter(resp.header, interval, maxInterval)
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+// resolveStatusURL determines the absolute status URL of an accepted operation.
+// Status URLs must point to the same host as the original request.
+func resolveStatusURL(requestURL *url.URL, resp *apiResponse, statusURLField string) (string, error) {
+	var location string
+	if statusURLField != "" {
+		var body any
+		if err := json.Unmarshal(resp.body, &body); err == nil {
+			if value, ok := lookupField(body, statusURLField); ok {
+				location, _ = value.(string)
+			}
+		}
+	}
+	if location == "" {
+		location = resp.header.Get("Location")
+	}
+	if location == "" {
+		location = resp.header.Get("Content-Location")
+	}
+	if location == "" {
+		return "", fmt.Errorf("202 Accepted response contains no status URL")
+	}
+
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid status URL %q: %w", location, err)
+	}
+	resolved := requestURL.ResolveReference(parsed)
+	if resolved.Host != requestURL.Host || resolved.Scheme != requestURL.Scheme {
+		return "", fmt.Errorf("status URL %s does not match API host %s", resolved, requestURL.Host)
+	}
+	return resolved.String(), nil
+}
+
+// retryAfter returns the delay requested by a Retry-After header, or fallback if none is present.
+// The result is capped at maxWait.
+func retryAfter(header http.Header, fallback, maxWait time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxWait)
+	}
+	return fallback
+}
+
+// extractStateField returns the string value of a (dot-separated) field in a JSON body.
+func extractStateField(body []byte, field string) string {
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return ""
+	}
+	value, ok := lookupField(data, field)
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// lookupField resolves a dot-separated path like "status.state" or "items.0.id" in decoded JSON.
+func lookupField(data any, path string) (any, bool) {
+	current := data
+	for _, part := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
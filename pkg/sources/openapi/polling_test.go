@@ -0,0 +1,179 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+func TestGetOpenAPIHandler_PollsAcceptedOperations(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs":
+			w.Header().Set("Location", "/jobs/1/status")
+			w.WriteHeader(http.StatusAccepted)
+		case "/jobs/1/status":
+			w.Header().Set("Content-Type", "application/json")
+			if polls.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"job": {"state": "running"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"job": {"state": "succeeded"}, "result": 42}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := newTestTool("POST", "/jobs")
+	tool.OpenAPIHandlerInput.Async = &AsyncPollingConfig{
+		StatusField:     "job.state",
+		InitialInterval: 1,
+		MaxInterval:     5,
+	}
+
+	notifier := &recordingNotifier{}
+	execContext := core.NewBasicExecutionContext("test_tool", map[string]any{}, "")
+	execContext.SetProgressNotifier(notifier)
+
+	result, err := GetOpenAPIHandler(tool, NewAPIClient(server.URL, 5))(context.Background(), execContext)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() != nil {
+		t.Fatalf("Expected successful result, got error: %v", result.GetError())
+	}
+
+	if !strings.Contains(result.GetContent(), `"result": 42`) {
+		t.Errorf("Expected final status response in content, got: %s", result.GetContent())
+	}
+	if attempts, _ := result.GetMetadata().Get("pollAttempts"); attempts != 3 {
+		t.Errorf("Expected 3 poll attempts, got %v", attempts)
+	}
+	if state, _ := result.GetMetadata().Get("asyncState"); state != "succeeded" {
+		t.Errorf("Expected async state 'succeeded', got %v", state)
+	}
+	if len(notifier.messages) != 3 {
+		t.Errorf("Expected 3 progress notifications, got %d", len(notifier.messages))
+	}
+}
+
+func TestGetOpenAPIHandler_AcceptedWithoutPollingConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/jobs/1/status")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	handler := GetOpenAPIHandler(newTestTool("POST", "/jobs"), NewAPIClient(server.URL, 5))
+	result, err := handler(context.Background(), core.NewBasicExecutionContext("test_tool", map[string]any{}, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if status, _ := result.GetMetadata().Get("httpStatus"); status != http.StatusAccepted {
+		t.Errorf("Expected 202 status without polling, got %v", status)
+	}
+	if _, polled := result.GetMetadata().Get("asyncPolled"); polled {
+		t.Error("Expected no polling without async config")
+	}
+}
+
+func TestResolveStatusURL(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.example.com/v1/jobs")
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    string
+		field   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "relative Location header",
+			header: http.Header{"Location": []string{"/v1/jobs/1"}},
+			want:   "https://api.example.com/v1/jobs/1",
+		},
+		{
+			name:   "status URL from body field",
+			header: http.Header{},
+			body:   `{"links": {"status": "https://api.example.com/v1/status/1"}}`,
+			field:  "links.status",
+			want:   "https://api.example.com/v1/status/1",
+		},
+		{
+			name:    "foreign host is rejected",
+			header:  http.Header{"Location": []string{"https://evil.example.com/jobs/1"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing status URL",
+			header:  http.Header{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &apiResponse{header: tt.header, body: []byte(tt.body)}
+			got, err := resolveStatusURL(requestURL, resp, tt.field)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got URL %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetAsyncPollingConfig_FromExtension(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Async", "version": "1.0"},
+		"paths": {
+			"/export": {"post": {"operationId": "export", "x-mcp-async": true, "responses": {"202": {"description": "accepted"}}}},
+			"/import": {"post": {"operationId": "import", "x-mcp-async": {"statusField": "state", "timeout": 60}, "responses": {"202": {"description": "accepted"}}}},
+			"/sync": {"post": {"operationId": "sync", "responses": {"200": {"description": "ok"}}}}
+		}
+	}`
+	tools := createToolsFromSpec(t, spec)
+
+	if tools["export"].OpenAPIHandlerInput.Async == nil {
+		t.Error("Expected polling to be enabled for x-mcp-async: true")
+	}
+	importConfig := tools["import"].OpenAPIHandlerInput.Async
+	if importConfig == nil || importConfig.StatusField != "state" || importConfig.Timeout != 60 {
+		t.Errorf("Expected polling config from extension object, got %+v", importConfig)
+	}
+	if tools["sync"].OpenAPIHandlerInput.Async != nil {
+		t.Error("Expected polling to be disabled without extension")
+	}
+}
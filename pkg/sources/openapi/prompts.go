@@ -0,0 +1,271 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// operationExample is a named example request of an operation.
+type operationExample struct {
+	name    string
+	summary string
+	value   any // Decoded request body, nil if the example only sets parameters
+}
+
+// CreatePrompts creates prompts guiding the model through the API: one per tag listing
+// the tools of the tag, and one per request example of an operation.
+func (a *LibopenAPIAdapter) CreatePrompts(doc *libopenapi.DocumentModel[v3.Document], tools []OpenAPIMcpTool) []core.McpPrompt {
+	title, _ := a.GetDocumentInfo(doc)
+	prompts := createTagPrompts(title, doc.Model.Tags, tools)
+
+	names := make(map[string]bool, len(prompts))
+	for _, prompt := range prompts {
+		names[prompt.Name] = true
+	}
+	for i := range tools {
+		for _, prompt := range createExamplePrompts(&tools[i]) {
+			if names[prompt.Name] {
+				log.Printf("Skipping prompt %s: a prompt with this name already exists", prompt.Name)
+				continue
+			}
+			names[prompt.Name] = true
+			prompts = append(prompts, prompt)
+		}
+	}
+	return prompts
+}
+
+// createTagPrompts creates one prompt per tag summarizing the tools of its operations.
+// Tags declared in the document come first, followed by tags only used by operations.
+func createTagPrompts(title string, declaredTags []*base.Tag, tools []OpenAPIMcpTool) []core.McpPrompt {
+	var tagNames []string
+	descriptions := make(map[string]string)
+	for _, tag := range declaredTags {
+		tagNames = append(tagNames, tag.Name)
+		descriptions[tag.Name] = tag.Description
+	}
+	toolsByTag := make(map[string][]*OpenAPIMcpTool)
+	for i := range tools {
+		if tools[i].Operation == nil {
+			continue
+		}
+		for _, tag := range tools[i].Operation.Tags {
+			if !slices.Contains(tagNames, tag) {
+				tagNames = append(tagNames, tag)
+			}
+			toolsByTag[tag] = append(toolsByTag[tag], &tools[i])
+		}
+	}
+
+	var prompts []core.McpPrompt
+	for _, tag := range tagNames {
+		tagTools := toolsByTag[tag]
+		if len(tagTools) == 0 {
+			continue
+		}
+		var content strings.Builder
+		fmt.Fprintf(&content, "The %s API offers the following tools for %s", title, tag)
+		if descriptions[tag] != "" {
+			fmt.Fprintf(&content, " (%s)", strings.TrimSpace(descriptions[tag]))
+		}
+		content.WriteString(":\n")
+		for _, tool := range tagTools {
+			fmt.Fprintf(&content, "- %s: %s\n", tool.Name, getToolSummary(tool))
+		}
+		content.WriteString("\nUse these tools to help with my request. Prefer calling a tool over asking me for data the API can provide.")
+
+		prompts = append(prompts, core.McpPrompt{
+			Name:        sanitizeToolName(tag + "_overview"),
+			Description: fmt.Sprintf("Overview of the %s operations of the %s API", tag, title),
+			Messages:    []core.McpPromptMessage{{Role: "user", Content: content.String()}},
+		})
+	}
+	return prompts
+}
+
+// getToolSummary returns a one line summary of a tool's operation.
+func getToolSummary(tool *OpenAPIMcpTool) string {
+	if tool.Operation != nil && tool.Operation.Summary != "" {
+		return strings.TrimSpace(tool.Operation.Summary)
+	}
+	summary, _, _ := strings.Cut(strings.TrimSpace(tool.Description), "\n")
+	return summary
+}
+
+// createExamplePrompts creates one prompt per request body example of a tool's operation,
+// or a single prompt if only parameters have examples. The prompts ask the model to call the
+// tool with the example arguments; required parameters without example become prompt arguments.
+func createExamplePrompts(tool *OpenAPIMcpTool) []core.McpPrompt {
+	if tool.Operation == nil || tool.OpenAPIHandlerInput == nil {
+		return nil
+	}
+	parameterValues := getParameterExamples(tool.Operation)
+	examples := getRequestBodyExamples(tool.Operation, tool.OpenAPIHandlerInput.ContentType)
+	if len(examples) == 0 {
+		if len(parameterValues) == 0 {
+			return nil
+		}
+		examples = []operationExample{{}}
+	}
+
+	var prompts []core.McpPrompt
+	for _, example := range examples {
+		arguments := make(map[string]any)
+		for name, value := range parameterValues {
+			arguments[name] = value
+		}
+		if _, raw := tool.InputSchema.Properties["body__body"]; raw && example.value != nil {
+			arguments["body__body"] = formatValue(example.value)
+		} else if object, ok := example.value.(map[string]any); ok {
+			for name, value := range object {
+				arguments["body__"+name] = value
+			}
+		}
+		// Only arguments the tool accepts, fixed and hidden parameters are not part of its schema
+		for name := range arguments {
+			if _, ok := tool.InputSchema.Properties[name]; !ok {
+				delete(arguments, name)
+			}
+		}
+
+		var promptArguments []core.McpPromptArgument
+		for _, name := range tool.InputSchema.Required {
+			if _, ok := arguments[name]; ok {
+				continue
+			}
+			promptArguments = append(promptArguments, core.McpPromptArgument{
+				Name:        name,
+				Description: getPropertyDescription(tool.InputSchema.Properties[name]),
+				Required:    true,
+			})
+			arguments[name] = fmt.Sprintf("{{%s}}", name)
+		}
+
+		data, err := json.MarshalIndent(arguments, "", "  ")
+		if err != nil {
+			log.Printf("Skipping example %s of %s: %v", example.name, tool.Name, err)
+			continue
+		}
+
+		name := tool.Name + "_example"
+		description := fmt.Sprintf("Example call of %s", tool.Name)
+		if example.name != "" {
+			name += "_" + example.name
+			description += " (" + example.name + ")"
+		}
+		if example.summary != "" {
+			description = example.summary
+		}
+		prompts = append(prompts, core.McpPrompt{
+			Name:        sanitizeToolName(name),
+			Description: description,
+			Tool:        tool.Name,
+			Arguments:   promptArguments,
+			Messages: []core.McpPromptMessage{{
+				Role:    "user",
+				Content: fmt.Sprintf("%s.\n\nCall the %s tool with these arguments:\n```json\n%s\n```", description, tool.Name, data),
+			}},
+		})
+	}
+	return prompts
+}
+
+// getPropertyDescription returns the description of an input schema property.
+func getPropertyDescription(property any) string {
+	if schema, ok := property.(map[string]any); ok {
+		description, _ := schema["description"].(string)
+		return description
+	}
+	return ""
+}
+
+// getParameterExamples returns the example values of an operation's parameters by prefixed name.
+func getParameterExamples(operation *v3.Operation) map[string]any {
+	values := make(map[string]any)
+	for _, param := range operation.Parameters {
+		node := param.Example
+		if node == nil {
+			if examples := getExamplesInOrder(param.Examples); len(examples) > 0 {
+				node = examples[0].value.(*yaml.Node)
+			}
+		}
+		if value, ok := decodeYAMLValue(node); ok {
+			values[fmt.Sprintf("%s__%s", param.In, param.Name)] = value
+		}
+	}
+	return values
+}
+
+// getRequestBodyExamples returns the request body examples of the media type used by the tool.
+func getRequestBodyExamples(operation *v3.Operation, contentType string) []operationExample {
+	if !hasRequestBody(operation) {
+		return nil
+	}
+	media, ok := operation.RequestBody.Content.Get(contentType)
+	if !ok {
+		media = operation.RequestBody.Content.First().Value()
+	}
+
+	var examples []operationExample
+	if value, ok := decodeYAMLValue(media.Example); ok {
+		examples = append(examples, operationExample{value: value})
+	}
+	for _, example := range getExamplesInOrder(media.Examples) {
+		if value, ok := decodeYAMLValue(example.value.(*yaml.Node)); ok {
+			examples = append(examples, operationExample{name: example.name, summary: example.summary, value: value})
+		}
+	}
+	return examples
+}
+
+// getExamplesInOrder returns named examples in document order, with their undecoded values.
+func getExamplesInOrder(examples *orderedmap.Map[string, *base.Example]) []operationExample {
+	if examples == nil {
+		return nil
+	}
+	var result []operationExample
+	for pair := examples.First(); pair != nil; pair = pair.Next() {
+		if pair.Value() == nil || pair.Value().Value == nil {
+			continue
+		}
+		result = append(result, operationExample{name: pair.Key(), summary: pair.Value().Summary, value: pair.Value().Value})
+	}
+	return result
+}
+
+// decodeYAMLValue decodes a value given in the spec, e.g. an example or enum value; ok is false if there is none.
+func decodeYAMLValue(node *yaml.Node) (any, bool) {
+	if node == nil {
+		return nil, false
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		log.Printf("Unable to decode value: %v", err)
+		return nil, false
+	}
+	return value, value != nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+const promptsSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets API", "version": "1.0.0"},
+  "tags": [{"name": "pets", "description": "Everything about pets"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "summary": "List all pets",
+        "tags": ["pets"],
+        "parameters": [{"name": "limit", "in": "query", "example": 10, "schema": {"type": "integer"}}],
+        "responses": {"200": {"description": "ok"}}
+      },
+      "post": {
+        "operationId": "createPet",
+        "summary": "Create a pet",
+        "tags": ["pets"],
+        "requestBody": {"content": {"application/json": {
+          "schema": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "tag": {"type": "string"}}},
+          "examples": {
+            "dog": {"summary": "Create a dog", "value": {"name": "Rex", "tag": "dog"}},
+            "cat": {"value": {"name": "Tom", "tag": "cat"}}
+          }
+        }}},
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/stores/{storeId}/pets": {
+      "put": {
+        "operationId": "stockPets",
+        "tags": ["stores"],
+        "parameters": [{"name": "storeId", "in": "path", "required": true, "description": "Store to stock", "schema": {"type": "string"}}],
+        "requestBody": {"content": {"application/json": {
+          "schema": {"type": "object", "properties": {"count": {"type": "integer"}}},
+          "example": {"count": 3}
+        }}},
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+func TestCreatePrompts(t *testing.T) {
+	document, err := libopenapi.NewDocumentWithConfiguration([]byte(promptsSpec), datamodel.NewDocumentConfiguration())
+	if err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+	docModel, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		t.Fatalf("Failed to build model: %v", errs[0])
+	}
+	adapter := NewLibopenAPIAdapter()
+	tools, err := adapter.CreateToolsFromDocument(docModel)
+	if err != nil {
+		t.Fatalf("Failed to create tools: %v", err)
+	}
+
+	prompts := adapter.CreatePrompts(docModel, tools)
+	var names []string
+	for _, prompt := range prompts {
+		names = append(names, prompt.Name)
+	}
+	expected := []string{
+		"pets_overview",
+		"stores_overview",
+		"listpets_example",
+		"createpet_example_dog",
+		"createpet_example_cat",
+		"stockpets_example",
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected prompts %v, got %v", expected, names)
+	}
+
+	overview := prompts[0].Messages[0].Content
+	for _, want := range []string{"Pets API", "Everything about pets", "- listpets: List all pets", "- createpet: Create a pet"} {
+		if !strings.Contains(overview, want) {
+			t.Errorf("Expected tag prompt to contain %q, got:\n%s", want, overview)
+		}
+	}
+
+	dog := prompts[3]
+	if dog.Description != "Create a dog" || !strings.Contains(dog.Messages[0].Content, `"body__name": "Rex"`) {
+		t.Errorf("Expected example prompt with body arguments, got %+v", dog)
+	}
+	if listPets := prompts[2].Messages[0].Content; !strings.Contains(listPets, `"query__limit": 10`) {
+		t.Errorf("Expected parameter example in prompt, got:\n%s", listPets)
+	}
+
+	stock := prompts[5]
+	if len(stock.Arguments) != 1 || stock.Arguments[0].Name != "path__storeId" || !stock.Arguments[0].Required {
+		t.Fatalf("Expected required parameter without example as prompt argument, got %+v", stock.Arguments)
+	}
+	messages, err := stock.Render(map[string]string{"path__storeId": "s1"})
+	if err != nil {
+		t.Fatalf("Failed to render prompt: %v", err)
+	}
+	if !strings.Contains(messages[0].Content, `"path__storeId": "s1"`) || !strings.Contains(messages[0].Content, `"body__count": 3`) {
+		t.Errorf("Expected rendered arguments, got:\n%s", messages[0].Content)
+	}
+}
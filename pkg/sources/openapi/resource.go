@@ -0,0 +1,123 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// pathParamPattern matches path templating in OpenAPI paths, e.g. "{userId}".
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// invalidURIVariableChars matches characters not allowed in RFC 6570 variable names.
+var invalidURIVariableChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// toolResource describes how a GET operation tool is exposed as an MCP resource.
+type toolResource struct {
+	resource  core.McpResource
+	variables map[string]string // URI template variable to prefixed path parameter
+}
+
+// newToolResource returns the resource a tool is exposed as. Only GET operations qualify whose
+// required parameters are all path parameters; path parameters become URI template variables.
+// Returns nil if the tool cannot be exposed as resource.
+func newToolResource(tool *OpenAPIMcpTool, baseURL string) *toolResource {
+	input := tool.OpenAPIHandlerInput
+	if input == nil || input.Workflow != nil || !strings.EqualFold(input.Method, http.MethodGet) {
+		return nil
+	}
+	for _, name := range tool.InputSchema.Required {
+		if !strings.HasPrefix(name, "path__") {
+			return nil
+		}
+	}
+
+	variables := make(map[string]string)
+	ambiguous := false
+	path := pathParamPattern.ReplaceAllStringFunc(input.Path, func(match string) string {
+		param := "path__" + match[1:len(match)-1]
+		if value, fixed := input.FixedParams[param]; fixed {
+			return url.PathEscape(fmt.Sprintf("%v", value))
+		}
+		variable := invalidURIVariableChars.ReplaceAllString(match[1:len(match)-1], "_")
+		if _, exists := variables[variable]; exists {
+			ambiguous = true
+		}
+		variables[variable] = param
+		return "{" + variable + "}"
+	})
+	if ambiguous {
+		return nil
+	}
+
+	return &toolResource{
+		resource: core.McpResource{
+			URI:         baseURL + path,
+			Name:        tool.Name,
+			Description: tool.Description,
+			MIMEType:    getResourceMIMEType(input.Accept),
+			IsTemplate:  len(variables) > 0,
+		},
+		variables: variables,
+	}
+}
+
+// getResourceMIMEType returns the preferred media type of an Accept header.
+func getResourceMIMEType(accept string) string {
+	preferred, _, _ := strings.Cut(accept, ",")
+	mediaType, _, _ := strings.Cut(preferred, ";")
+	return strings.TrimSpace(mediaType)
+}
+
+// GetResourceHandler creates a handler reading the resource of a GET operation tool.
+// The request is built like for GetOpenAPIHandler; the content is the response body,
+// error status codes fail the read.
+func GetResourceHandler(makeMcpTool *OpenAPIMcpTool, variables map[string]string, apiClient *APIClient) core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		params := make(map[string]any, len(variables))
+		for variable, value := range request.GetParameters() {
+			if param, ok := variables[variable]; ok {
+				params[param] = value
+			}
+		}
+
+		call, err := apiClient.callOperation(ctx, makeMcpTool, core.NewBasicExecutionContext(makeMcpTool.Name, params, ""))
+		if err != nil {
+			return core.NewBasicExecutionError(err), nil
+		}
+		resp := call.response
+		if resp.statusCode >= 400 {
+			return core.NewBasicExecutionError(fmt.Errorf("HTTP %s %s\nStatus: %d\nResponse: %s", resp.method, resp.url, resp.statusCode, string(resp.body))), nil
+		}
+
+		executionResult := core.NewBasicExecutionResult(string(resp.body), nil)
+		metadata := executionResult.GetMetadata()
+		metadata.Set("httpStatus", resp.statusCode)
+		metadata.Set("finalURL", resp.url)
+		metadata.Set("truncated", resp.truncated)
+		if contentType := resp.header.Get("Content-Type"); contentType != "" {
+			mediaType, _, _ := strings.Cut(contentType, ";")
+			metadata.Set("mimeType", strings.TrimSpace(mediaType))
+		}
+		return executionResult, nil
+	}
+}
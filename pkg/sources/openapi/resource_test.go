@@ -0,0 +1,148 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const resourcesSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Users API", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "get": {
+        "operationId": "listUsers",
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {}}}}
+      },
+      "post": {
+        "operationId": "createUser",
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/users/{user-id}": {
+      "get": {
+        "operationId": "getUser",
+        "parameters": [{"name": "user-id", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {}}}}
+      }
+    },
+    "/search": {
+      "get": {
+        "operationId": "searchUsers",
+        "parameters": [{"name": "q", "in": "query", "required": true, "schema": {"type": "string"}}],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+func TestNewToolResource(t *testing.T) {
+	tools := createToolsFromSpec(t, resourcesSpec)
+
+	tests := []struct {
+		tool         string
+		wantExposed  bool
+		wantURI      string
+		wantTemplate bool
+	}{
+		{"listusers", true, "https://api.example.com/users", false},
+		{"getuser", true, "https://api.example.com/users/{user_id}", true},
+		{"createuser", false, "", false},
+		{"searchusers", false, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			resource := newToolResource(tools[tt.tool], "https://api.example.com")
+			if (resource != nil) != tt.wantExposed {
+				t.Fatalf("Expected exposed=%v, got %+v", tt.wantExposed, resource)
+			}
+			if resource == nil {
+				return
+			}
+			if resource.resource.URI != tt.wantURI || resource.resource.IsTemplate != tt.wantTemplate {
+				t.Errorf("Expected URI %s (template=%v), got %+v", tt.wantURI, tt.wantTemplate, resource.resource)
+			}
+			if resource.resource.MIMEType != "application/json" {
+				t.Errorf("Expected MIME type from the declared responses, got %q", resource.resource.MIMEType)
+			}
+		})
+	}
+}
+
+func TestGetResourceHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/7" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"id": "7"}`))
+	}))
+	defer server.Close()
+
+	params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.DevMode = true
+	params.Specs = writeTestFile(t, t.TempDir(), "users.json", resourcesSpec)
+	params.BaseURL = server.URL
+	params.Resources = true
+
+	source := NewOpenAPISource()
+	app, err := source.Parse(params)
+	if err != nil {
+		t.Fatalf("Expected no error from Parse but got: %v", err)
+	}
+	if err := source.AttachToolHandlers(app); err != nil {
+		t.Fatalf("Failed to attach handlers: %v", err)
+	}
+	var getUser *OpenAPIMcpTool
+	for _, tool := range app.Tools {
+		if tool.GetName() == "getuser" {
+			getUser = tool.(*OpenAPIMcpTool)
+		}
+	}
+	if _, exposed := getUser.ToMcpResource(); !exposed {
+		t.Fatal("Expected getuser to be exposed as resource")
+	}
+	handler := getUser.GetResourceHandler()
+
+	result, err := handler(context.Background(), core.NewBasicExecutionContext("getuser", map[string]any{"user_id": "7"}, ""))
+	if err != nil || result.GetError() != nil {
+		t.Fatalf("Expected resource read to succeed, got: %v %v", err, result.GetError())
+	}
+	if result.GetContent() != `{"id": "7"}` {
+		t.Errorf("Expected the response body as content, got %q", result.GetContent())
+	}
+	if mimeType, _ := result.GetMetadata().Get("mimeType"); mimeType != "application/json" {
+		t.Errorf("Expected mimeType application/json, got %v", mimeType)
+	}
+
+	result, err = handler(context.Background(), core.NewBasicExecutionContext("getuser", map[string]any{"user_id": "8"}, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() == nil || !strings.Contains(result.GetError().Error(), "Status: 404") {
+		t.Errorf("Expected error status to fail the read, got: %v", result.GetError())
+	}
+}
@@ -15,6 +15,8 @@
 package openapi
 
 import (
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	core "github.com/T4cceptor/MakeMCP/pkg/core"
@@ -176,3 +178,126 @@ func TestOpenAPISource_Name(t *testing.T) {
 		t.Errorf("Expected name 'openapi', got %s", source.Name())
 	}
 }
+
+func TestOpenAPISource_ApplyOverrides(t *testing.T) {
+	source := NewOpenAPISource()
+	uploadDir := t.TempDir()
+	newParams := func() *OpenAPIParams {
+		params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+		params.Specs = "spec.json"
+		params.BaseURL = "https://staging.example.com"
+		return params
+	}
+
+	tests := []struct {
+		name        string
+		flags       map[string]any
+		expectError bool
+		setup       func(params *OpenAPIParams)
+		check       func(t *testing.T, params *OpenAPIParams)
+	}{
+		{
+			name: "Runtime parameters",
+			flags: map[string]any{
+				"base-url":          "https://api.example.com",
+				"timeout":           5,
+				"max-response-size": 1024,
+				"max-retries":       2,
+				"file-upload-dir":   uploadDir,
+			},
+			check: func(t *testing.T, params *OpenAPIParams) {
+				if params.BaseURL != "https://api.example.com" || params.Timeout != 5 || params.MaxResponseSize != 1024 ||
+					params.MaxRetries != 2 || params.FileUploadDir != uploadDir {
+					t.Errorf("Expected overridden params, got %+v", params)
+				}
+			},
+		},
+		{
+			name: "No overrides",
+			check: func(t *testing.T, params *OpenAPIParams) {
+				if params.BaseURL != "https://staging.example.com" {
+					t.Errorf("Expected saved base URL, got %s", params.BaseURL)
+				}
+			},
+		},
+		{
+			name:  "Relative locations of the config file",
+			flags: map[string]any{"timeout": 5},
+			setup: func(params *OpenAPIParams) {
+				params.Overlays = []string{"overlays/public.yaml"}
+				params.Arazzo = "workflows.arazzo.yaml"
+				params.FileUploadDir = "uploads"
+			},
+			check: func(t *testing.T, params *OpenAPIParams) {
+				if params.Timeout != 5 || params.FileUploadDir != "uploads" {
+					t.Errorf("Expected only the timeout to be overridden, got %+v", params)
+				}
+			},
+		},
+		{
+			name:        "Invalid file upload directory",
+			flags:       map[string]any{"file-upload-dir": filepath.Join(uploadDir, "missing")},
+			expectError: true,
+		},
+		{
+			name:        "Unknown flag",
+			flags:       map[string]any{"specs": "other.json"},
+			expectError: true,
+		},
+		{
+			name:        "Wrong value type",
+			flags:       map[string]any{"timeout": "5"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid value",
+			flags:       map[string]any{"timeout": -1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := newParams()
+			if tt.setup != nil {
+				tt.setup(params)
+			}
+			err := source.ApplyOverrides(params, tt.flags)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			tt.check(t, params)
+		})
+	}
+}
+
+func TestOpenAPISource_ParseYAMLSpec(t *testing.T) {
+	source := NewOpenAPISource()
+	parse := func(specs string) map[string]bool {
+		params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+		params.Specs = specs
+		params.BaseURL = "https://api.example.com/v1"
+		params.DevMode = true
+		app, err := source.Parse(params)
+		if err != nil {
+			t.Fatalf("Expected no error parsing %s but got: %v", specs, err)
+		}
+		toolNames := make(map[string]bool)
+		for _, tool := range app.Tools {
+			toolNames[tool.GetName()] = true
+		}
+		return toolNames
+	}
+
+	jsonTools := parse("../../../testbed/openapi/sample_specifications/simplewithbody.json")
+	yamlTools := parse("../../../testbed/openapi/sample_specifications/simplewithbody.yaml")
+	if len(yamlTools) == 0 || !reflect.DeepEqual(jsonTools, yamlTools) {
+		t.Errorf("Expected the YAML spec to create the tools of the JSON spec %v, got %v", jsonTools, yamlTools)
+	}
+}
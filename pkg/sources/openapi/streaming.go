@@ -0,0 +1,133 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"strings"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// streamingContentTypes are response media types that deliver results incrementally.
+var streamingContentTypes = []string{
+	"text/event-stream",
+	"application/x-ndjson",
+	"application/ndjson",
+	"application/jsonl",
+	"application/json-seq",
+	"application/stream+json",
+}
+
+// isStreamingContentType returns true if the given Content-Type header denotes a streaming response.
+func isStreamingContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return contains(streamingContentTypes, mediaType)
+}
+
+// streamResult holds the accumulated outcome of reading a streaming response.
+type streamResult struct {
+	data      []byte
+	chunks    int
+	truncated bool
+}
+
+// readStreamingResponse reads a streaming response chunk by chunk, forwarding every chunk
+// to the notifier while accumulating the payloads into the final result.
+// Server-sent events are split on blank lines and only their data fields are kept,
+// all other streaming types are treated as newline-delimited records.
+// If reading fails, the chunks received so far are returned along with the error.
+func readStreamingResponse(
+	ctx context.Context,
+	body io.Reader,
+	contentType string,
+	limit int64,
+	notifier core.ProgressNotifier,
+) (streamResult, error) {
+	var result streamResult
+	var accumulated bytes.Buffer
+	var eventData []string
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	isSSE := mediaType == "text/event-stream"
+
+	emit := func(chunk string) {
+		if chunk == "" {
+			return
+		}
+		if accumulated.Len() > 0 {
+			accumulated.WriteByte('\n')
+		}
+		accumulated.WriteString(chunk)
+		result.chunks++
+		if err := notifier.NotifyProgress(float64(result.chunks), 0, chunk); err != nil {
+			log.Printf("Failed to forward stream chunk %d: %v", result.chunks, err)
+		}
+	}
+
+	reader := bufio.NewReader(io.LimitReader(body, limit+1))
+	var read int64
+	var readErr error
+	for {
+		if readErr = ctx.Err(); readErr != nil {
+			break
+		}
+
+		line, err := reader.ReadString('\n')
+		read += int64(len(line))
+		if read > limit {
+			result.truncated = true
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if isSSE {
+			switch {
+			case line == "":
+				emit(strings.Join(eventData, "\n"))
+				eventData = nil
+			case strings.HasPrefix(line, "data:"):
+				eventData = append(eventData, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+			// Comments and event/id/retry fields carry no payload
+		} else {
+			emit(strings.TrimSpace(line))
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			readErr = err
+			break
+		}
+	}
+
+	// Dispatch a trailing event that was not terminated by a blank line
+	if isSSE && !result.truncated && readErr == nil {
+		emit(strings.Join(eventData, "\n"))
+	}
+
+	result.data = accumulated.Bytes()
+	return result, readErr
+}
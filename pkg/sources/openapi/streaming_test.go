@@ -0,0 +1,219 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// recordingNotifier collects all progress messages it receives.
+type recordingNotifier struct {
+	messages []string
+}
+
+func (r *recordingNotifier) NotifyProgress(progress, total float64, message string) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestIsStreamingContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"text/event-stream", true},
+		{"text/event-stream; charset=utf-8", true},
+		{"application/x-ndjson", true},
+		{"application/json", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isStreamingContentType(tt.contentType); got != tt.want {
+			t.Errorf("isStreamingContentType(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestReadStreamingResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		wantChunks   []string
+		wantData     string
+		wantTruncate bool
+	}{
+		{
+			name:        "server-sent events",
+			contentType: "text/event-stream",
+			body:        ": comment\nevent: update\ndata: first\n\ndata: second\ndata: line\n\ndata: trailing",
+			wantChunks:  []string{"first", "second\nline", "trailing"},
+			wantData:    "first\nsecond\nline\ntrailing",
+		},
+		{
+			name:        "server-sent events with parameters in mixed case",
+			contentType: "Text/Event-Stream; charset=utf-8",
+			body:        "data: first\n\ndata: second\n\n",
+			wantChunks:  []string{"first", "second"},
+			wantData:    "first\nsecond",
+		},
+		{
+			name:        "newline-delimited JSON",
+			contentType: "application/x-ndjson",
+			body:        "{\"id\":1}\n\n{\"id\":2}\n",
+			wantChunks:  []string{`{"id":1}`, `{"id":2}`},
+			wantData:    "{\"id\":1}\n{\"id\":2}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			result, err := readStreamingResponse(
+				context.Background(), strings.NewReader(tt.body), tt.contentType, 1024, notifier,
+			)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if string(result.data) != tt.wantData {
+				t.Errorf("Expected data %q, got %q", tt.wantData, string(result.data))
+			}
+			if result.chunks != len(tt.wantChunks) {
+				t.Errorf("Expected %d chunks, got %d", len(tt.wantChunks), result.chunks)
+			}
+			if strings.Join(notifier.messages, "|") != strings.Join(tt.wantChunks, "|") {
+				t.Errorf("Expected forwarded chunks %v, got %v", tt.wantChunks, notifier.messages)
+			}
+		})
+	}
+}
+
+func TestReadStreamingResponse_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := readStreamingResponse(ctx, strings.NewReader("{}\n"), "application/x-ndjson", 1024, &recordingNotifier{})
+	if err == nil {
+		t.Error("Expected error for cancelled context, got nil")
+	}
+}
+
+func TestGetOpenAPIHandler_ForwardsStreamChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: one\n\ndata: two\n\n"))
+	}))
+	defer server.Close()
+
+	notifier := &recordingNotifier{}
+	execContext := core.NewBasicExecutionContext("test_tool", map[string]any{}, "")
+	execContext.SetProgressNotifier(notifier)
+
+	handler := GetOpenAPIHandler(newTestTool("GET", "/events"), NewAPIClient(server.URL, 5))
+	result, err := handler(context.Background(), execContext)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if len(notifier.messages) != 2 {
+		t.Errorf("Expected 2 forwarded chunks, got %v", notifier.messages)
+	}
+	if !strings.Contains(result.GetContent(), "one\ntwo") {
+		t.Errorf("Expected accumulated stream in result, got: %s", result.GetContent())
+	}
+	if streamed, _ := result.GetMetadata().Get("streamed"); streamed != true {
+		t.Errorf("Expected streamed metadata to be true, got %v", streamed)
+	}
+}
+
+func TestGetOpenAPIHandler_StreamOutlivesTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		stall         bool
+		wantContent   string
+		wantTruncated bool
+	}{
+		{name: "chunks keep arriving", wantContent: "0\n1\n2\n3\n4\n5"},
+		{name: "stream stalls", stall: true, wantContent: "0\n1\n2\n3\n4\n5", wantTruncated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				for i := range 6 {
+					_, _ = fmt.Fprintf(w, "%d\n", i)
+					w.(http.Flusher).Flush()
+					time.Sleep(50 * time.Millisecond)
+				}
+				if tt.stall {
+					<-r.Context().Done()
+				}
+			}))
+			defer server.Close()
+
+			// The stream takes longer than the timeout, but no chunk does
+			apiClient := NewAPIClient(server.URL, 5)
+			apiClient.Timeout = 200 * time.Millisecond
+			execContext := core.NewBasicExecutionContext("test_tool", map[string]any{}, "")
+			execContext.SetProgressNotifier(&recordingNotifier{})
+
+			handler := GetOpenAPIHandler(newTestTool("GET", "/events"), apiClient)
+			result, err := handler(context.Background(), execContext)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if result.GetError() != nil {
+				t.Fatalf("Expected partial result, got error: %v", result.GetError())
+			}
+			if !strings.Contains(result.GetContent(), tt.wantContent) {
+				t.Errorf("Expected stream content %q, got: %s", tt.wantContent, result.GetContent())
+			}
+			if truncated, _ := result.GetMetadata().Get("truncated"); truncated != tt.wantTruncated {
+				t.Errorf("Expected truncated metadata %v, got %v", tt.wantTruncated, truncated)
+			}
+			_, interrupted := result.GetMetadata().Get("streamError")
+			if interrupted != tt.wantTruncated {
+				t.Errorf("Expected stream error metadata %v, got %v", tt.wantTruncated, interrupted)
+			}
+		})
+	}
+}
+
+func TestAPIClient_TimeoutWithoutResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	apiClient := NewAPIClient(server.URL, 5)
+	apiClient.Timeout = 100 * time.Millisecond
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if _, err := apiClient.execute(context.Background(), req, "", core.NoopProgressNotifier{}); !errors.Is(err, errResponseTimeout) {
+		t.Errorf("Expected response timeout error, got %v", err)
+	}
+}
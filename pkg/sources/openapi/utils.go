@@ -15,7 +15,10 @@
 package openapi
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -24,20 +27,107 @@ import (
 	"time"
 )
 
+// DefaultMaxResponseSize is the default maximum number of bytes read from an HTTP response (10 MiB).
+const DefaultMaxResponseSize int64 = 10 * 1024 * 1024
+
 // APIClient struct to encapsulate baseURL and http.Client.
 type APIClient struct {
-	BaseURL    string
-	HTTPClient *http.Client
+	BaseURL         string
+	HTTPClient      *http.Client
+	Timeout         time.Duration // Maximum time to receive a response, streams may run longer as long as chunks keep arriving
+	MaxResponseSize int64         // Maximum number of response bytes read per request
+	MaxRetries      int           // Number of retries for transient failures of retryable requests
+	FileUploadDir   string        // Directory local files may be uploaded from, disabled if empty
+	Headers         http.Header   // Headers sent with every request, e.g. credentials
 }
 
 // NewAPIClient creates a new APIClient with the given baseURL and timeout.
 func NewAPIClient(baseURL string, timeoutSeconds int) *APIClient {
 	return &APIClient{
 		BaseURL: baseURL,
-		HTTPClient: &http.Client{
-			Timeout: time.Duration(timeoutSeconds) * time.Second,
-		},
+		// The timeout is applied per request by send, an overall client timeout would cut off streams
+		HTTPClient:      &http.Client{},
+		Timeout:         time.Duration(timeoutSeconds) * time.Second,
+		MaxResponseSize: DefaultMaxResponseSize,
+	}
+}
+
+// errResponseTimeout is the cause of requests cancelled by the client timeout.
+var errResponseTimeout = errors.New("response timeout exceeded")
+
+// send sends a single request. The client timeout covers receiving the response headers and body.
+// Streaming responses may take longer, see timedBody.
+func (c *APIClient) send(req *http.Request) (*http.Response, error) {
+	if c.Timeout <= 0 {
+		return c.HTTPClient.Do(req)
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(c.Timeout, func() { cancel(errResponseTimeout) })
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		timer.Stop()
+		if errors.Is(context.Cause(ctx), errResponseTimeout) {
+			err = fmt.Errorf("%w after %s: %w", errResponseTimeout, c.Timeout, err)
+		}
+		cancel(nil)
+		return nil, err
+	}
+	resp.Body = &timedBody{ReadCloser: resp.Body, ctx: ctx, timer: timer, cancel: cancel}
+	return resp, nil
+}
+
+// timedBody is a response body that is cancelled once its timer fires.
+type timedBody struct {
+	io.ReadCloser
+	ctx         context.Context
+	timer       *time.Timer
+	cancel      context.CancelCauseFunc
+	idleTimeout time.Duration // Restarts the timer on every read if set
+}
+
+// Read reads from the body and reports timeouts as errResponseTimeout.
+func (b *timedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && errors.Is(context.Cause(b.ctx), errResponseTimeout) {
+		err = errResponseTimeout
+	}
+	if n > 0 && b.idleTimeout > 0 {
+		b.timer.Reset(b.idleTimeout)
+	}
+	return n, err
+}
+
+// Close stops the timer and closes the body.
+func (b *timedBody) Close() error {
+	b.timer.Stop()
+	defer b.cancel(nil)
+	return b.ReadCloser.Close()
+}
+
+// allowIdleReads turns the timeout of a response body into an idle timeout,
+// so streams are only cancelled if no data arrives for the given duration.
+func allowIdleReads(body io.ReadCloser, idleTimeout time.Duration) {
+	if b, ok := body.(*timedBody); ok {
+		b.idleTimeout = idleTimeout
+		b.timer.Reset(idleTimeout)
+	}
+}
+
+// readLimited reads at most limit bytes from r.
+// Returns the bytes read and true if the input was longer than limit.
+func readLimited(r io.Reader, limit int64) ([]byte, bool, error) {
+	if limit <= 0 {
+		limit = DefaultMaxResponseSize
+	}
+	// Read one extra byte so we can tell whether anything was cut off
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return data, false, err
 	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+	return data, false, nil
 }
 
 // boolPtr returns a pointer to the given bool value.
@@ -227,3 +317,20 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// addStaticParams adds the headers, cookies and body fields configured for a tool to params,
+// overriding parameters of the same name. Body fields are only added to requests with a body.
+func addStaticParams(params ToolParams, input *OpenAPIHandlerInput) {
+	for name, value := range input.Headers {
+		params.Header[name] = value
+	}
+	for name, value := range input.Cookies {
+		params.Cookie[name] = value
+	}
+	if len(params.Body) == 0 {
+		return
+	}
+	for name, value := range input.BodyAppend {
+		params.Body[name] = value
+	}
+}
@@ -0,0 +1,458 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/speakeasy-api/jsonpath/pkg/jsonpath"
+	"github.com/speakeasy-api/jsonpath/pkg/jsonpath/config"
+	"gopkg.in/yaml.v3"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+// Success criterion types
+const (
+	CriterionTypeSimple   = "simple"   // Comparisons like "$statusCode == 201", combined with && and ||
+	CriterionTypeRegex    = "regex"    // Condition is a regular expression matched against the context
+	CriterionTypeJSONPath = "jsonpath" // Condition is a JSONPath query that must match within the context
+)
+
+// maxWorkflowErrorBody limits how much of a failed step's response is reported to the model.
+const maxWorkflowErrorBody = 1000
+
+// Workflow describes a composite tool that calls several operations in order.
+// Arguments, outputs and criteria use Arazzo runtime expressions like "$steps.create.outputs.id".
+type Workflow struct {
+	Steps   []WorkflowStep    `json:"steps"`
+	Outputs map[string]string `json:"outputs,omitempty"` // Workflow output name to expression
+}
+
+// WorkflowStep is a single operation call of a workflow.
+type WorkflowStep struct {
+	StepID          string             `json:"stepId"`
+	Description     string             `json:"description,omitempty"`
+	Tool            string             `json:"tool"`                      // Name of the tool executing the operation
+	Arguments       map[string]any     `json:"arguments,omitempty"`       // Prefixed tool parameters, values may contain expressions
+	SuccessCriteria []SuccessCriterion `json:"successCriteria,omitempty"` // All must hold, defaults to a status below 400
+	Outputs         map[string]string  `json:"outputs,omitempty"`         // Step output name to expression
+}
+
+// SuccessCriterion is a condition a step's response has to fulfill.
+type SuccessCriterion struct {
+	Context   string `json:"context,omitempty"` // Expression the condition applies to, required for regex and jsonpath
+	Condition string `json:"condition"`
+	Type      string `json:"type,omitempty"` // simple (default), regex or jsonpath
+}
+
+// stepResult holds the response and outputs of an executed step.
+type stepResult struct {
+	method     string
+	url        string
+	statusCode int
+	header     http.Header
+	body       any // Decoded JSON or XML, otherwise the raw body as string
+	outputs    map[string]any
+}
+
+// workflowState holds the data expressions of a running workflow can refer to.
+type workflowState struct {
+	inputs  map[string]any
+	steps   map[string]*stepResult
+	current *stepResult // Step whose response $statusCode, $response... refer to
+}
+
+// newStepResult decodes an API response for use in expressions.
+func newStepResult(resp *apiResponse) *stepResult {
+	result := &stepResult{
+		method:     resp.method,
+		url:        resp.url,
+		statusCode: resp.statusCode,
+		header:     resp.header,
+		body:       string(resp.body),
+		outputs:    make(map[string]any),
+	}
+	var decoded any
+	contentType := resp.header.Get("Content-Type")
+	if isXMLContentType(contentType) {
+		if structured, err := xmlToJSON(resp.body); err == nil {
+			result.body = structured
+		}
+	} else if json.Unmarshal(resp.body, &decoded) == nil {
+		result.body = decoded
+	}
+	return result
+}
+
+// GetWorkflowHandler creates a handler executing the steps of a composite tool in order.
+// Each step calls the operation of its tool like GetOpenAPIHandler does; the workflow stops
+// at the first step that fails or does not meet its success criteria.
+// The result contains the workflow outputs and the status of each executed step.
+func GetWorkflowHandler(makeMcpTool *OpenAPIMcpTool, tools map[string]*OpenAPIMcpTool, apiClient *APIClient) core.MakeMcpToolHandler {
+	return func(ctx context.Context, request core.ToolExecutionContext) (core.ToolExecutionResult, error) {
+		startTime := time.Now()
+		workflow := makeMcpTool.OpenAPIHandlerInput.Workflow
+		if workflow == nil {
+			return core.NewBasicExecutionResult("Error: ", errors.New("tool has no workflow definition")), nil
+		}
+
+		state := &workflowState{
+			inputs: request.GetParameters(),
+			steps:  make(map[string]*stepResult),
+		}
+		notifier := request.GetProgressNotifier()
+		var executed []map[string]any
+
+		for i, step := range workflow.Steps {
+			result, err := runWorkflowStep(ctx, step, state, tools, apiClient, request)
+			if result != nil {
+				executed = append(executed, map[string]any{"stepId": step.StepID, "tool": step.Tool, "status": result.statusCode})
+			}
+			if err != nil {
+				return core.NewBasicExecutionResult("Error: ", fmt.Errorf("workflow step %s failed: %w", step.StepID, err)), nil
+			}
+			_ = notifier.NotifyProgress(float64(i+1), float64(len(workflow.Steps)), fmt.Sprintf("step %s completed", step.StepID))
+		}
+
+		state.current = nil
+		outputs, err := resolveOutputs(workflow.Outputs, state)
+		if err != nil {
+			return core.NewBasicExecutionResult("Error: ", fmt.Errorf("failed to evaluate workflow outputs: %w", err)), nil
+		}
+		content := map[string]any{"outputs": outputs, "steps": executed}
+		data, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			return core.NewBasicExecutionResult("Error: ", fmt.Errorf("failed to encode workflow result: %w", err)), nil
+		}
+
+		executionResult := core.NewBasicExecutionResult(string(data), nil)
+		metadata := executionResult.GetMetadata()
+		metadata.Set("executionTime", time.Since(startTime))
+		metadata.Set("workflowSteps", len(executed))
+		metadata.Set("isJsonData", true)
+		metadata.Set("preferredFormat", "json")
+		return executionResult, nil
+	}
+}
+
+// runWorkflowStep executes a single step and records its result in state.
+func runWorkflowStep(ctx context.Context, step WorkflowStep, state *workflowState, tools map[string]*OpenAPIMcpTool, apiClient *APIClient, request core.ToolExecutionContext) (*stepResult, error) {
+	tool, ok := tools[step.Tool]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %s", step.Tool)
+	}
+
+	state.current = nil
+	resolved, err := resolveValue(step.Arguments, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve arguments: %w", err)
+	}
+	arguments, _ := resolved.(map[string]any)
+
+	stepContext := core.NewBasicExecutionContext(step.Tool, arguments, "")
+	if key, ok := request.GetMetadata().Get("idempotencyKey"); ok {
+		// Derive a key per step, so retried workflows do not repeat completed steps
+		stepContext.GetMetadata().Set("idempotencyKey", fmt.Sprintf("%v-%s", key, step.StepID))
+	}
+
+	call, err := apiClient.callOperation(ctx, tool, stepContext)
+	if err != nil {
+		return nil, err
+	}
+	result := newStepResult(call.response)
+	state.steps[step.StepID] = result
+	state.current = result
+
+	if err := checkSuccessCriteria(step.SuccessCriteria, state); err != nil {
+		body := string(call.response.body)
+		if len(body) > maxWorkflowErrorBody {
+			body = body[:maxWorkflowErrorBody] + "..."
+		}
+		return result, fmt.Errorf("%w\nHTTP %s %s\nStatus: %d\nResponse: %s", err, result.method, result.url, result.statusCode, body)
+	}
+
+	outputs, err := resolveOutputs(step.Outputs, state)
+	if err != nil {
+		return result, fmt.Errorf("failed to evaluate outputs: %w", err)
+	}
+	result.outputs = outputs
+	return result, nil
+}
+
+// resolveOutputs evaluates output expressions.
+func resolveOutputs(expressions map[string]string, state *workflowState) (map[string]any, error) {
+	outputs := make(map[string]any, len(expressions))
+	for name, expression := range expressions {
+		value, err := resolveValue(expression, state)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", name, err)
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
+// checkSuccessCriteria returns an error describing the first criterion the current step does not meet.
+func checkSuccessCriteria(criteria []SuccessCriterion, state *workflowState) error {
+	if len(criteria) == 0 {
+		if state.current.statusCode >= 400 {
+			return fmt.Errorf("unexpected status code %d", state.current.statusCode)
+		}
+		return nil
+	}
+	for _, criterion := range criteria {
+		ok, err := evaluateCriterion(criterion, state)
+		if err != nil {
+			return fmt.Errorf("invalid success criterion %q: %w", criterion.Condition, err)
+		}
+		if !ok {
+			return fmt.Errorf("success criterion not met: %s", criterion.Condition)
+		}
+	}
+	return nil
+}
+
+// evaluateCriterion evaluates a single success criterion against the current step.
+func evaluateCriterion(criterion SuccessCriterion, state *workflowState) (bool, error) {
+	switch strings.ToLower(criterion.Type) {
+	case "", CriterionTypeSimple:
+		return evaluateCondition(criterion.Condition, state)
+	case CriterionTypeRegex:
+		context, err := evaluateExpression(criterion.Context, state)
+		if err != nil {
+			return false, err
+		}
+		pattern, err := regexp.Compile(criterion.Condition)
+		if err != nil {
+			return false, err
+		}
+		return pattern.MatchString(formatValue(context)), nil
+	case CriterionTypeJSONPath:
+		contextExpression := criterion.Context
+		if contextExpression == "" {
+			contextExpression = "$response.body"
+		}
+		context, err := evaluateExpression(contextExpression, state)
+		if err != nil {
+			return false, err
+		}
+		return matchesJSONPath(criterion.Condition, context)
+	default:
+		return false, fmt.Errorf("unsupported criterion type %s", criterion.Type)
+	}
+}
+
+// matchesJSONPath returns true if the JSONPath query selects at least one node of data.
+func matchesJSONPath(query string, data any) (bool, error) {
+	path, err := jsonpath.NewPath(query, config.WithPropertyNameExtension())
+	if err != nil {
+		return false, err
+	}
+	var node yaml.Node
+	if err := node.Encode(data); err != nil {
+		return false, err
+	}
+	return len(path.Query(&node)) > 0, nil
+}
+
+// comparisonOperators in order of matching, two character operators first.
+var comparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// evaluateCondition evaluates simple conditions like "$statusCode == 200 && $response.body#/ok == true".
+// Conditions are combined with || and &&, where && binds stronger; parentheses are not supported.
+func evaluateCondition(condition string, state *workflowState) (bool, error) {
+	for _, alternative := range splitOutsideQuotes(condition, "||") {
+		matched := true
+		for _, comparison := range splitOutsideQuotes(alternative, "&&") {
+			ok, err := evaluateComparison(strings.TrimSpace(comparison), state)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateComparison evaluates a single comparison, or the truthiness of a single operand.
+func evaluateComparison(comparison string, state *workflowState) (bool, error) {
+	for _, operator := range comparisonOperators {
+		parts := splitOutsideQuotes(comparison, operator)
+		if len(parts) != 2 {
+			continue
+		}
+		left, err := evaluateOperand(parts[0], state)
+		if err != nil {
+			return false, err
+		}
+		right, err := evaluateOperand(parts[1], state)
+		if err != nil {
+			return false, err
+		}
+		return compareValues(left, right, operator)
+	}
+
+	value, err := evaluateOperand(comparison, state)
+	if err != nil {
+		return false, err
+	}
+	return isTruthy(value), nil
+}
+
+// evaluateOperand evaluates a runtime expression or parses a literal.
+func evaluateOperand(operand string, state *workflowState) (any, error) {
+	operand = strings.TrimSpace(operand)
+	switch {
+	case strings.HasPrefix(operand, "$"):
+		return evaluateExpression(operand, state)
+	case len(operand) >= 2 && (operand[0] == '\'' || operand[0] == '"') && operand[len(operand)-1] == operand[0]:
+		return operand[1 : len(operand)-1], nil
+	case operand == "true":
+		return true, nil
+	case operand == "false":
+		return false, nil
+	case operand == "null":
+		return nil, nil
+	}
+	if number, err := strconv.ParseFloat(operand, 64); err == nil {
+		return number, nil
+	}
+	return operand, nil
+}
+
+// compareValues compares two operands, numerically if both are numbers.
+func compareValues(left, right any, operator string) (bool, error) {
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		switch operator {
+		case "==":
+			return leftNumber == rightNumber, nil
+		case "!=":
+			return leftNumber != rightNumber, nil
+		case "<":
+			return leftNumber < rightNumber, nil
+		case "<=":
+			return leftNumber <= rightNumber, nil
+		case ">":
+			return leftNumber > rightNumber, nil
+		case ">=":
+			return leftNumber >= rightNumber, nil
+		}
+	}
+
+	switch operator {
+	case "==":
+		return formatValue(left) == formatValue(right), nil
+	case "!=":
+		return formatValue(left) != formatValue(right), nil
+	default:
+		return false, fmt.Errorf("operator %s requires numeric operands, got %v and %v", operator, left, right)
+	}
+}
+
+// toNumber converts numeric values and numeric strings to float64.
+func toNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// isTruthy returns false for nil, false, zero, and empty strings or collections.
+func isTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+	if number, ok := toNumber(value); ok {
+		return number != 0
+	}
+	return true
+}
+
+// formatValue formats a value for string comparison and interpolation; objects become JSON.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// splitOutsideQuotes splits s at every separator that is not inside a quoted string.
+func splitOutsideQuotes(s, separator string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case strings.HasPrefix(s[i:], separator):
+			// Do not split "<=" at "<" or "==" at "="
+			if len(separator) == 1 && i+1 < len(s) && s[i+1] == '=' {
+				continue
+			}
+			parts = append(parts, s[start:i])
+			start = i + len(separator)
+			i += len(separator) - 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	core "github.com/T4cceptor/MakeMCP/pkg/core"
+)
+
+const ordersSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Orders API", "version": "1.0.0"},
+  "paths": {
+    "/orders": {
+      "post": {
+        "operationId": "createOrder",
+        "requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"customer": {"type": "string"}}}}}},
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/orders/{orderId}/items": {
+      "post": {
+        "operationId": "addItem",
+        "parameters": [{"name": "orderId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+        "requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"sku": {"type": "string"}}}}}},
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/orders/{orderId}/confirm": {
+      "post": {
+        "operationId": "confirmOrder",
+        "parameters": [{"name": "orderId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+const ordersArazzo = `arazzo: 1.0.0
+info:
+  title: Order workflows
+  version: 1.0.0
+sourceDescriptions:
+  - name: orders
+    url: ./orders.json
+    type: openapi
+workflows:
+  - workflowId: place-order
+    summary: Create an order, add an item and confirm it.
+    inputs:
+      type: object
+      required: [customer, sku]
+      properties:
+        customer:
+          type: string
+        sku:
+          type: string
+    steps:
+      - stepId: create
+        operationId: createOrder
+        requestBody:
+          contentType: application/json
+          payload:
+            customer: $inputs.customer
+        successCriteria:
+          - condition: $statusCode == 201
+        outputs:
+          orderId: $response.body#/id
+      - stepId: addItem
+        operationPath: '{$sourceDescriptions.orders.url}#/paths/~1orders~1{orderId}~1items/post'
+        parameters:
+          - name: orderId
+            in: path
+            value: $steps.create.outputs.orderId
+        requestBody:
+          payload:
+            sku: $inputs.sku
+            note: "Added for {$inputs.customer}"
+      - stepId: confirm
+        operationId: $sourceDescriptions.orders.confirmOrder
+        parameters:
+          - name: orderId
+            value: $steps.create.outputs.orderId
+        successCriteria:
+          - condition: $statusCode == 200 && $response.body#/state == 'confirmed'
+          - context: $response.header.X-Trace
+            condition: ^trace-
+            type: regex
+        outputs:
+          state: $response.body#/state
+    outputs:
+      orderId: $steps.create.outputs.orderId
+      state: $steps.confirm.outputs.state
+`
+
+// newOrdersServer serves the orders API; confirmState is returned by the confirm operation.
+func newOrdersServer(t *testing.T, confirmState string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.ToUpper(r.Method)+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Trace", "trace-1")
+		switch r.URL.Path {
+		case "/orders":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 42}`))
+		case "/orders/42/items":
+			_, _ = w.Write([]byte(`{"items": 1}`))
+		case "/orders/42/confirm":
+			_, _ = w.Write([]byte(`{"state": "` + confirmState + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// parseWorkflowApp parses the orders spec with its Arazzo document and attaches all handlers.
+func parseWorkflowApp(t *testing.T, baseURL string) map[string]*OpenAPIMcpTool {
+	t.Helper()
+	dir := t.TempDir()
+	params := NewOpenAPIParams(core.NewBaseParams("openapi", core.TransportTypeStdio))
+	params.DevMode = true
+	params.Specs = writeTestFile(t, dir, "orders.json", ordersSpec)
+	params.Arazzo = writeTestFile(t, dir, "orders.arazzo.yaml", ordersArazzo)
+	params.BaseURL = baseURL
+
+	source := NewOpenAPISource()
+	app, err := source.Parse(params)
+	if err != nil {
+		t.Fatalf("Expected no error from Parse but got: %v", err)
+	}
+	if err := source.AttachToolHandlers(app); err != nil {
+		t.Fatalf("Failed to attach handlers: %v", err)
+	}
+	tools := make(map[string]*OpenAPIMcpTool)
+	for _, tool := range app.Tools {
+		tools[tool.GetName()] = tool.(*OpenAPIMcpTool)
+	}
+	return tools
+}
+
+func TestWorkflowTool_ExecutesSteps(t *testing.T) {
+	server, requests := newOrdersServer(t, "confirmed")
+	tools := parseWorkflowApp(t, server.URL)
+
+	workflowTool := tools["place_order"]
+	if workflowTool == nil {
+		t.Fatalf("Expected workflow tool place_order, got %v", tools)
+	}
+	if len(workflowTool.InputSchema.Required) != 2 || workflowTool.InputSchema.Properties["sku"] == nil {
+		t.Errorf("Expected input schema from workflow inputs, got %+v", workflowTool.InputSchema)
+	}
+	if workflowTool.Annotations.ReadOnlyHint == nil || *workflowTool.Annotations.ReadOnlyHint {
+		t.Error("Expected workflow with POST steps not to be read-only")
+	}
+
+	params := map[string]any{"customer": "jane", "sku": "A-1"}
+	result, err := workflowTool.GetHandler()(context.Background(), core.NewBasicExecutionContext("place_order", params, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() != nil {
+		t.Fatalf("Expected workflow to succeed, got: %v", result.GetError())
+	}
+
+	var content struct {
+		Outputs map[string]any   `json:"outputs"`
+		Steps   []map[string]any `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(result.GetContent()), &content); err != nil {
+		t.Fatalf("Expected JSON result, got %s", result.GetContent())
+	}
+	if content.Outputs["orderId"] != float64(42) || content.Outputs["state"] != "confirmed" {
+		t.Errorf("Unexpected workflow outputs: %v", content.Outputs)
+	}
+	if len(content.Steps) != 3 {
+		t.Errorf("Expected 3 executed steps, got %v", content.Steps)
+	}
+
+	expected := []string{
+		`POST /orders {"customer":"jane"}`,
+		`POST /orders/42/items {"note":"Added for jane","sku":"A-1"}`,
+		`POST /orders/42/confirm `,
+	}
+	if strings.Join(*requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected requests:\n%s\nwant:\n%s", strings.Join(*requests, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestWorkflowTool_StopsOnFailedCriteria(t *testing.T) {
+	server, _ := newOrdersServer(t, "pending")
+	tools := parseWorkflowApp(t, server.URL)
+
+	params := map[string]any{"customer": "jane", "sku": "A-1"}
+	result, err := tools["place_order"].GetHandler()(context.Background(), core.NewBasicExecutionContext("place_order", params, ""))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.GetError() == nil {
+		t.Fatal("Expected workflow to fail")
+	}
+	message := result.GetError().Error()
+	if !strings.Contains(message, "workflow step confirm failed") || !strings.Contains(message, "pending") {
+		t.Errorf("Expected failure of step confirm with its response, got: %s", message)
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	state := &workflowState{
+		inputs: map[string]any{"limit": 5},
+		steps:  map[string]*stepResult{},
+		current: &stepResult{
+			statusCode: 200,
+			header:     http.Header{"Content-Type": []string{"application/json"}},
+			body:       map[string]any{"items": []any{map[string]any{"id": "a&b"}}, "count": float64(3)},
+		},
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"$statusCode == 200", true},
+		{"$statusCode != 200", false},
+		{"$statusCode >= 200 && $statusCode < 300", true},
+		{"$statusCode == 404 || $response.body#/count > 2", true},
+		{"$response.body#/count <= $inputs.limit", true},
+		{"$response.body#/items/0/id == 'a&b'", true},
+		{"$response.header.Content-Type == \"application/json\"", true},
+		{"$response.body#/missing", false},
+		{"$response.body#/items", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			got, err := evaluateCondition(tt.condition, state)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := evaluateCondition("$response.body#/items > 1", state); err == nil {
+		t.Error("Expected error comparing non-numeric values")
+	}
+}
+
+func TestEvaluateCriterion_JSONPath(t *testing.T) {
+	state := &workflowState{current: &stepResult{body: map[string]any{"pets": []any{map[string]any{"name": "rex"}}}}}
+
+	ok, err := evaluateCriterion(SuccessCriterion{Condition: "$.pets[?(@.name == 'rex')]", Type: CriterionTypeJSONPath}, state)
+	if err != nil || !ok {
+		t.Errorf("Expected JSONPath criterion to match, got %v (err: %v)", ok, err)
+	}
+	ok, err = evaluateCriterion(SuccessCriterion{Condition: "$.pets[?(@.name == 'tom')]", Type: CriterionTypeJSONPath}, state)
+	if err != nil || ok {
+		t.Errorf("Expected JSONPath criterion not to match, got %v (err: %v)", ok, err)
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	state := &workflowState{
+		inputs: map[string]any{"name": "jane", "tags": []any{"a"}},
+		steps:  map[string]*stepResult{"create": {outputs: map[string]any{"id": 7}}},
+	}
+	value := map[string]any{
+		"id":       "$steps.create.outputs.id",
+		"greeting": "Hello {$inputs.name}, order {$steps.create.outputs.id}",
+		"tags":     "$inputs.tags",
+		"optional": "$inputs.missing",
+		"literal":  []any{"plain", 1},
+	}
+	resolved, err := resolveValue(value, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result := resolved.(map[string]any)
+	if result["id"] != 7 || result["greeting"] != "Hello jane, order 7" {
+		t.Errorf("Unexpected resolved values: %v", result)
+	}
+	if _, exists := result["optional"]; exists {
+		t.Error("Expected missing optional inputs to be dropped")
+	}
+
+	if _, err := resolveValue("$steps.unknown.outputs.id", state); err == nil {
+		t.Error("Expected error for unknown step")
+	}
+	if _, err := resolveValue("$statusCode", state); err == nil {
+		t.Error("Expected error for response expressions outside of a step")
+	}
+}
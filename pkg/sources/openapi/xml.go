@@ -0,0 +1,302 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// maxXMLSchemaDepth limits how deep (possibly recursive) schemas are followed for XML hints.
+const maxXMLSchemaDepth = 10
+
+// defaultXMLRootName is used as root element if neither the schema nor its reference provide a name.
+const defaultXMLRootName = "root"
+
+// XMLNode describes how a schema (property) is serialized to XML, following the OpenAPI xml object.
+type XMLNode struct {
+	Property   string     `json:"property,omitempty"`   // JSON property name this node serializes
+	Name       string     `json:"name,omitempty"`       // Element or attribute name, defaults to Property
+	Namespace  string     `json:"namespace,omitempty"`  // Namespace URI declared on the element
+	Prefix     string     `json:"prefix,omitempty"`     // Namespace prefix of the element
+	Attribute  bool       `json:"attribute,omitempty"`  // Serialize as attribute instead of element
+	Wrapped    bool       `json:"wrapped,omitempty"`    // Wrap array items in an element named after the array
+	Properties []*XMLNode `json:"properties,omitempty"` // Object properties in schema order
+	Items      *XMLNode   `json:"items,omitempty"`      // Array item description
+}
+
+// newXMLNode builds the XML description of a schema, including all nested properties and items.
+func newXMLNode(property string, schemaProxy *base.SchemaProxy, depth int) *XMLNode {
+	node := &XMLNode{Property: property}
+	if schemaProxy == nil || depth > maxXMLSchemaDepth {
+		return node
+	}
+	schema := schemaProxy.Schema()
+	if schema == nil {
+		return node
+	}
+
+	if schema.XML != nil {
+		node.Name = schema.XML.Name
+		node.Namespace = schema.XML.Namespace
+		node.Prefix = schema.XML.Prefix
+		node.Attribute = schema.XML.Attribute
+		node.Wrapped = schema.XML.Wrapped
+	}
+	if schema.Properties != nil {
+		for propPairs := schema.Properties.First(); propPairs != nil; propPairs = propPairs.Next() {
+			node.Properties = append(node.Properties, newXMLNode(propPairs.Key(), propPairs.Value(), depth+1))
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		node.Items = newXMLNode("", schema.Items.A, depth+1)
+	}
+	return node
+}
+
+// newXMLRootNode builds the XML description of a request body schema.
+// The root element is named by the schema's xml name, its component name, or "root".
+func newXMLRootNode(schemaProxy *base.SchemaProxy) *XMLNode {
+	node := newXMLNode("", schemaProxy, 0)
+	if node.Name == "" && schemaProxy != nil && schemaProxy.IsReference() {
+		reference := schemaProxy.GetReference()
+		node.Name = reference[strings.LastIndex(reference, "/")+1:]
+	}
+	if node.Name == "" {
+		node.Name = defaultXMLRootName
+	}
+	return node
+}
+
+// elementName returns the qualified element name of a node.
+func (n *XMLNode) elementName(fallback string) string {
+	name := n.Name
+	if name == "" {
+		name = n.Property
+	}
+	if name == "" {
+		name = fallback
+	}
+	if n.Prefix != "" {
+		return n.Prefix + ":" + name
+	}
+	return name
+}
+
+// namespaceAttr returns the namespace declaration of a node, if any.
+func (n *XMLNode) namespaceAttr() string {
+	if n.Namespace == "" {
+		return ""
+	}
+	if n.Prefix != "" {
+		return fmt.Sprintf(` xmlns:%s="%s"`, n.Prefix, escapeXML(n.Namespace))
+	}
+	return fmt.Sprintf(` xmlns="%s"`, escapeXML(n.Namespace))
+}
+
+// property returns the child node describing the given property, or nil if unknown.
+func (n *XMLNode) property(name string) *XMLNode {
+	if n == nil {
+		return nil
+	}
+	for _, child := range n.Properties {
+		if child.Property == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// marshalXML serializes values as the content of the root node.
+func marshalXML(root *XMLNode, values map[string]any) ([]byte, error) {
+	if root == nil {
+		root = &XMLNode{Name: defaultXMLRootName}
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := writeXMLElement(&buf, root, defaultXMLRootName, values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXMLElement writes value as element described by node.
+// Arrays are written as repeated elements, wrapped in an outer element if requested.
+func writeXMLElement(buf *bytes.Buffer, node *XMLNode, fallbackName string, value any) error {
+	if node == nil {
+		node = &XMLNode{}
+	}
+	name := node.elementName(fallbackName)
+
+	if items, isArray := value.([]any); isArray {
+		itemNode := node.Items
+		if itemNode == nil {
+			itemNode = &XMLNode{}
+		}
+		itemName := name
+		if node.Wrapped {
+			fmt.Fprintf(buf, "<%s%s>", name, node.namespaceAttr())
+			itemName = itemNode.elementName(name)
+		}
+		for _, item := range items {
+			if err := writeXMLElement(buf, itemNode, itemName, item); err != nil {
+				return err
+			}
+		}
+		if node.Wrapped {
+			fmt.Fprintf(buf, "</%s>", name)
+		}
+		return nil
+	}
+
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		fmt.Fprintf(buf, "<%s%s>%s</%s>", name, node.namespaceAttr(), escapeXML(formatXMLValue(value)), name)
+		return nil
+	}
+
+	// Schema properties come first in schema order, unknown properties follow sorted by name
+	var keys []string
+	for _, child := range node.Properties {
+		if _, ok := object[child.Property]; ok {
+			keys = append(keys, child.Property)
+		}
+	}
+	var unknown []string
+	for key := range object {
+		if node.property(key) == nil {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	keys = append(keys, unknown...)
+
+	fmt.Fprintf(buf, "<%s%s", name, node.namespaceAttr())
+	for _, key := range keys {
+		if child := node.property(key); child != nil && child.Attribute {
+			fmt.Fprintf(buf, ` %s="%s"`, child.elementName(key), escapeXML(formatXMLValue(object[key])))
+		}
+	}
+	buf.WriteString(">")
+	for _, key := range keys {
+		child := node.property(key)
+		if child != nil && child.Attribute {
+			continue
+		}
+		if err := writeXMLElement(buf, child, key, object[key]); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(buf, "</%s>", name)
+	return nil
+}
+
+// formatXMLValue formats a scalar value as XML text.
+func formatXMLValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// escapeXML escapes text for use in XML content and attribute values.
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// isXMLContentType returns true if the Content-Type header denotes an XML document.
+func isXMLContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// xmlToJSON converts an XML document into a JSON compatible structure.
+// Attributes are prefixed with "@", text next to child elements is stored as "#text",
+// and repeated elements become arrays.
+func xmlToJSON(data []byte) (map[string]any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("XML document contains no root element")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{start.Name.Local: value}, nil
+		}
+	}
+}
+
+// decodeXMLElement decodes the element opened by start into a string or map.
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	result := map[string]any{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		result["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			key := t.Name.Local
+			switch existing := result[key].(type) {
+			case nil:
+				result[key] = child
+			case []any:
+				result[key] = append(existing, child)
+			default:
+				result[key] = []any{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(result) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				result["#text"] = content
+			}
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 MakeMCP Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const xmlPetSpec = `
+openapi: 3.0.0
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: Success
+components:
+  schemas:
+    Pet:
+      type: object
+      xml:
+        namespace: http://example.com/schema
+        prefix: ex
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+          xml:
+            name: petName
+        photoUrls:
+          type: array
+          xml:
+            wrapped: true
+          items:
+            type: string
+            xml:
+              name: url
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func TestMarshalXML(t *testing.T) {
+	tools := createToolsFromSpec(t, xmlPetSpec)
+	tool, ok := tools["createpet"]
+	if !ok {
+		t.Fatalf("Expected tool createpet, got %v", tools)
+	}
+	if tool.OpenAPIHandlerInput.XMLSchema == nil {
+		t.Fatal("Expected XML schema to be extracted for structured XML body")
+	}
+
+	body, contentType, err := buildRequestBody(ToolParams{Body: map[string]any{
+		"id":        float64(7),
+		"name":      "Rex & Co",
+		"photoUrls": []any{"a.png", "b.png"},
+		"tags":      []any{"dog", "good"},
+		"extra":     true,
+	}}, tool, "")
+	if err != nil {
+		t.Fatalf("Failed to build XML body: %v", err)
+	}
+	if contentType != "application/xml" {
+		t.Errorf("Expected content type application/xml, got %s", contentType)
+	}
+	data, _ := io.ReadAll(body)
+
+	expected := `<ex:Pet xmlns:ex="http://example.com/schema" id="7">` +
+		`<petName>Rex &amp; Co</petName>` +
+		`<photoUrls><url>a.png</url><url>b.png</url></photoUrls>` +
+		`<tags>dog</tags><tags>good</tags>` +
+		`<extra>true</extra>` +
+		`</ex:Pet>`
+	if got := strings.TrimPrefix(string(data), `<?xml version="1.0" encoding="UTF-8"?>`+"\n"); got != expected {
+		t.Errorf("Unexpected XML body:\n got: %s\nwant: %s", got, expected)
+	}
+}
+
+func TestXMLContentTypeHandler_BuildRequestBody(t *testing.T) {
+	handler := &XMLContentTypeHandler{}
+
+	raw, err := handler.BuildRequestBody(map[string]any{"body": "<pet/>"})
+	if err != nil {
+		t.Fatalf("Unexpected error for raw XML: %v", err)
+	}
+	if data, _ := io.ReadAll(raw); string(data) != "<pet/>" {
+		t.Errorf("Expected raw XML to be passed through, got %s", data)
+	}
+
+	structured, err := handler.BuildRequestBody(map[string]any{"b": "2", "a": "1"})
+	if err != nil {
+		t.Fatalf("Unexpected error for structured XML: %v", err)
+	}
+	data, _ := io.ReadAll(structured)
+	if !strings.HasSuffix(string(data), "<root><a>1</a><b>2</b></root>") {
+		t.Errorf("Expected generic root element with sorted children, got %s", data)
+	}
+}
+
+func TestXMLToJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "simple element",
+			input:    `<name>Rex</name>`,
+			expected: map[string]any{"name": "Rex"},
+		},
+		{
+			name: "attributes, repeated elements and namespaces",
+			input: `<?xml version="1.0"?>
+<ex:pet xmlns:ex="http://example.com/schema" id="7">
+  <name>Rex</name>
+  <tag>dog</tag>
+  <tag>good</tag>
+</ex:pet>`,
+			expected: map[string]any{"pet": map[string]any{
+				"@id":  "7",
+				"name": "Rex",
+				"tag":  []any{"dog", "good"},
+			}},
+		},
+		{
+			name:     "mixed content",
+			input:    `<note lang="en">hello</note>`,
+			expected: map[string]any{"note": map[string]any{"@lang": "en", "#text": "hello"}},
+		},
+		{
+			name:    "no root element",
+			input:   ``,
+			wantErr: true,
+		},
+		{
+			name:    "malformed document",
+			input:   `<pet><name>Rex</pet>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := xmlToJSON([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsXMLContentType(t *testing.T) {
+	tests := map[string]bool{
+		"application/xml":                 true,
+		"text/xml; charset=utf-8":         true,
+		"application/atom+xml":            true,
+		"application/json":                false,
+		"application/xml-dtd-but-invalid": false,
+	}
+	for contentType, expected := range tests {
+		if got := isXMLContentType(contentType); got != expected {
+			t.Errorf("isXMLContentType(%q) = %v, want %v", contentType, got, expected)
+		}
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Test data structure for OpenAPI integration tests
@@ -43,6 +45,13 @@ func getTestCases() []testCase {
 			expectedResultFile: "expected_results/simplewithbody_makemcp.json",
 			expectedSource:     "openapi",
 		},
+		{
+			name:               "SimpleWithBodyYAML",
+			specFile:           "sample_specifications/simplewithbody.yaml",
+			baseURL:            "https://api.example.com/v1",
+			expectedResultFile: "expected_results/simplewithbody_makemcp.json",
+			expectedSource:     "openapi",
+		},
 		{
 			name:               "FastAPI",
 			specFile:           "sample_specifications/fastapi.json",
@@ -260,9 +269,10 @@ func TestOpenAPISpecValidation(t *testing.T) {
 				t.Fatalf("Failed to read spec file: %v", err)
 			}
 
+			// JSON specs are valid YAML as well
 			var spec map[string]any
-			if err := json.Unmarshal(specData, &spec); err != nil {
-				t.Fatalf("Failed to parse spec JSON: %v", err)
+			if err := yaml.Unmarshal(specData, &spec); err != nil {
+				t.Fatalf("Failed to parse spec: %v", err)
 			}
 
 			// Validate basic OpenAPI structure